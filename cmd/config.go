@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/credentials"
+	"github.com/wgpsec/lc/pkg/inventory"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// configFileMode 配置文件中保存了访问凭证，只允许当前用户读写
+const configFileMode = 0600
+
+// Config 执行 lc config 的子命令
+func (r *Runner) Config() {
+	if len(r.options.Args) == 0 {
+		gologger.Fatal().Msgf("请指定 config 的子命令: validate、list、add、test、encrypt、decrypt")
+	}
+	var err error
+	switch r.options.Args[0] {
+	case "validate":
+		err = r.validateConfig()
+	case "list":
+		r.listConfig()
+	case "add":
+		err = r.addConfig()
+	case "test":
+		err = r.testConfig()
+	case "encrypt":
+		err = r.encryptConfig()
+	case "decrypt":
+		err = r.decryptConfig()
+	default:
+		err = fmt.Errorf("未知的 config 子命令: %s", r.options.Args[0])
+	}
+	if err != nil {
+		gologger.Fatal().Msgf("%s", err)
+	}
+}
+
+// configIssue 是配置校验发现的问题，fatal 为 true 时配置无法使用
+type configIssue struct {
+	id      string
+	fatal   bool
+	message string
+}
+
+// validateConfig 检查配置中的云服务商、服务、字段名和访问凭证
+func (r *Runner) validateConfig() error {
+	var errorCount int
+	issues := checkConfig(r.config)
+	for _, issue := range issues {
+		if issue.fatal {
+			errorCount++
+			gologger.Error().Msgf("[%s] %s", issue.id, issue.message)
+		} else {
+			gologger.Info().Msgf("[%s] 警告: %s", issue.id, issue.message)
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("配置文件 %s 中发现 %d 个错误", r.options.Config, errorCount)
+	}
+	gologger.Info().Msgf("配置文件 %s 校验通过，共 %d 个配置", r.options.Config, len(r.config))
+	return nil
+}
+
+func checkConfig(config schema.Options) []configIssue {
+	var issues []configIssue
+	ids := make(map[string]bool)
+	for index, block := range config {
+		id, ok := block.GetMetadata(utils.Id)
+		if !ok {
+			id = fmt.Sprintf("#%d", index+1)
+			issues = append(issues, configIssue{id: id, fatal: true, message: "未填写 id"})
+		} else if ids[id] {
+			issues = append(issues, configIssue{id: id, fatal: true, message: "id 与其他配置重复"})
+		}
+		ids[id] = true
+
+		for key := range block {
+			if !utils.Contains(utils.ConfigKeys, key) && !strings.HasSuffix(key, schema.EndpointKeySuffix) {
+				issues = append(issues, configIssue{id: id, message: fmt.Sprintf("未知的字段 %s", key)})
+			}
+		}
+
+		provider, ok := block.GetMetadata(utils.Provider)
+		services, known := utils.ProviderServices[provider]
+		if !ok {
+			issues = append(issues, configIssue{id: id, fatal: true, message: "未填写 provider"})
+			continue
+		}
+		if !known {
+			issues = append(issues, configIssue{id: id, fatal: true, message: fmt.Sprintf("未知的云服务商 %s", provider)})
+			continue
+		}
+
+		cloudServices, ok := block.GetMetadata(utils.CloudServices)
+		if !ok {
+			issues = append(issues, configIssue{id: id, message: "未填写 cloud_services，只能通过 -cs 参数指定要列出的服务"})
+		}
+		for _, service := range strings.Split(cloudServices, ",") {
+			if service = strings.TrimSpace(service); service != "" && !utils.Contains(services, service) {
+				issues = append(issues, configIssue{id: id, fatal: true, message: fmt.Sprintf(
+					"%s 不支持服务 %s，可用的服务: %s", provider, service, strings.Join(services, ","))})
+			}
+		}
+		if _, err := utils.GetProxy(block); err != nil {
+			issues = append(issues, configIssue{id: id, fatal: true, message: err.Error()})
+		}
+
+		resolved := make(schema.OptionBlock, len(block))
+		for key, value := range block {
+			resolved[key] = value
+		}
+		if err := credentials.Resolve(resolved); err != nil {
+			issues = append(issues, configIssue{id: id, fatal: true, message: err.Error()})
+			continue
+		}
+		_, okAK := resolved.GetMetadata(utils.AccessKey)
+		_, okSK := resolved.GetMetadata(utils.SecretKey)
+		if !okAK || !okSK {
+			issues = append(issues, configIssue{id: id, fatal: true, message: "未找到访问凭证，请填写 access_key 和 secret_key"})
+		}
+	}
+	return issues
+}
+
+// listConfig 列出配置中的 id、云服务商和服务，访问凭证只显示首尾部分
+func (r *Runner) listConfig() {
+	for _, block := range r.config {
+		id, _ := block.GetMetadata(utils.Id)
+		provider, _ := block.GetMetadata(utils.Provider)
+		cloudServices, _ := block.GetMetadata(utils.CloudServices)
+		accessKey, _ := block.GetMetadata(utils.AccessKey)
+		if r.options.JSON {
+			data, err := json.Marshal(map[string]string{
+				utils.Id:            id,
+				utils.Provider:      provider,
+				utils.CloudServices: cloudServices,
+				utils.AccessKey:     maskSecret(accessKey),
+			})
+			if err != nil {
+				continue
+			}
+			gologger.Silent().Msgf("%s", data)
+			continue
+		}
+		gologger.Silent().Msgf("[%s] %s 服务: %s, 访问凭证: %s", id, provider, cloudServices, maskSecret(accessKey))
+	}
+}
+
+// maskSecret 只保留访问凭证的前 4 位和后 4 位
+func maskSecret(secret string) string {
+	if secret == "" {
+		return "未填写"
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "****" + secret[len(secret)-4:]
+}
+
+// testConfig 校验配置并探测每个配置的访问凭证能否访问对应的服务
+func (r *Runner) testConfig() error {
+	if err := r.validateConfig(); err != nil {
+		return err
+	}
+	for _, block := range r.filterConfig() {
+		provider, _ := block.GetMetadata(utils.Provider)
+		id, _ := block.GetMetadata(utils.Id)
+		results, err := inventory.Probe(block, r.options.CloudServices)
+		if err != nil {
+			gologger.Error().Msgf("[%s] %s 测试失败: %s", id, provider, err)
+			continue
+		}
+		var allowed []string
+		for _, result := range results {
+			if result.Status == schema.ProbeAllowed {
+				allowed = append(allowed, result.Service)
+			}
+		}
+		gologger.Silent().Msgf("[%s] %s 可以访问 %d/%d 个服务: %s", id, provider, len(allowed), len(results),
+			strings.Join(allowed, ","))
+	}
+	return nil
+}
+
+// configBlock 用于按固定的字段顺序写入新的配置
+type configBlock struct {
+	Provider      string `yaml:"provider"`
+	Id            string `yaml:"id"`
+	CloudServices string `yaml:"cloud_services"`
+	AccessKey     string `yaml:"access_key"`
+	SecretKey     string `yaml:"secret_key"`
+	SessionToken  string `yaml:"session_token,omitempty"`
+}
+
+// addConfig 通过交互的方式添加一个配置，并追加到配置文件末尾，-c 指定的是配置目录时写入目录下以 id 命名的新文件
+func (r *Runner) addConfig() error {
+	var (
+		block  configBlock
+		err    error
+		reader = bufio.NewReader(os.Stdin)
+	)
+	providers := make([]string, 0, len(utils.ProviderServices))
+	for provider := range utils.ProviderServices {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	if block.Provider, err = readLine(reader, fmt.Sprintf("云服务商 (%s): ", strings.Join(providers, ","))); err != nil {
+		return err
+	}
+	services, ok := utils.ProviderServices[block.Provider]
+	if !ok {
+		return fmt.Errorf("未知的云服务商 %s", block.Provider)
+	}
+	if block.Id, err = readLine(reader, "配置 id: "); err != nil {
+		return err
+	}
+	for _, item := range r.config {
+		if item[utils.Id] == block.Id {
+			return fmt.Errorf("id %s 已存在", block.Id)
+		}
+	}
+	if block.CloudServices, err = readLine(reader, fmt.Sprintf("要列出的服务 (默认 %s): ", strings.Join(services, ","))); err != nil {
+		return err
+	}
+	if block.CloudServices == "" {
+		block.CloudServices = strings.Join(services, ",")
+	}
+	if block.AccessKey, err = readLine(reader, "access_key: "); err != nil {
+		return err
+	}
+	if block.SecretKey, err = readSecret(reader, "secret_key: "); err != nil {
+		return err
+	}
+	if block.SessionToken, err = readSecret(reader, "session_token (可选): "); err != nil {
+		return err
+	}
+
+	newBlock := schema.OptionBlock{
+		utils.Provider: block.Provider, utils.Id: block.Id, utils.CloudServices: block.CloudServices,
+		utils.AccessKey: block.AccessKey, utils.SecretKey: block.SecretKey,
+	}
+	for _, issue := range checkConfig(schema.Options{newBlock}) {
+		if issue.fatal {
+			return fmt.Errorf("%s", issue.message)
+		}
+	}
+	data, err := yaml.Marshal([]configBlock{block})
+	if err != nil {
+		return err
+	}
+	configFile := r.options.Config
+	if info, err := os.Stat(configFile); err == nil && info.IsDir() {
+		configFile = filepath.Join(configFile, block.Id+".yaml")
+	}
+	if err := appendConfigFile(configFile, data); err != nil {
+		return err
+	}
+	gologger.Info().Msgf("已将配置 %s 添加到 %s", block.Id, configFile)
+	return nil
+}
+
+// appendConfigFile 将新的配置追加到配置文件末尾，加密的配置文件会在解密追加后重新加密
+func appendConfigFile(configFile string, block []byte) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	encrypted := utils.IsEncryptedConfig(data)
+	var passphrase string
+	if encrypted {
+		if passphrase, err = utils.ConfigPassphrase(false); err != nil {
+			return err
+		}
+		if data, err = utils.DecryptConfig(data, passphrase); err != nil {
+			return err
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, block...)
+	if encrypted {
+		if data, err = utils.EncryptConfig(data, passphrase); err != nil {
+			return err
+		}
+	}
+	return writeConfigFile(configFile, data)
+}
+
+func readLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// readSecret 在终端中读取访问凭证时不回显输入的内容
+func readSecret(reader *bufio.Reader, prompt string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return readLine(reader, prompt)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return strings.TrimSpace(string(secret)), err
+}
+
+// encryptConfig 使用口令加密配置文件
+func (r *Runner) encryptConfig() error {
+	data, err := os.ReadFile(r.options.Config)
+	if err != nil {
+		return err
+	}
+	if utils.IsEncryptedConfig(data) {
+		return fmt.Errorf("配置文件 %s 已经是加密的", r.options.Config)
+	}
+	passphrase, err := utils.ConfigPassphrase(true)
+	if err != nil {
+		return err
+	}
+	encrypted, err := utils.EncryptConfig(data, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := writeConfigFile(r.options.Config, encrypted); err != nil {
+		return err
+	}
+	gologger.Info().Msgf("配置文件 %s 已加密", r.options.Config)
+	return nil
+}
+
+// decryptConfig 将加密的配置文件还原为明文
+func (r *Runner) decryptConfig() error {
+	data, err := os.ReadFile(r.options.Config)
+	if err != nil {
+		return err
+	}
+	if !utils.IsEncryptedConfig(data) {
+		return fmt.Errorf("配置文件 %s 未加密", r.options.Config)
+	}
+	passphrase, err := utils.ConfigPassphrase(false)
+	if err != nil {
+		return err
+	}
+	decrypted, err := utils.DecryptConfig(data, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := writeConfigFile(r.options.Config, decrypted); err != nil {
+		return err
+	}
+	gologger.Info().Msgf("配置文件 %s 已解密", r.options.Config)
+	return nil
+}
+
+// writeConfigFile 写入配置文件并确保只有当前用户可以读写
+func writeConfigFile(configFile string, data []byte) error {
+	if err := os.WriteFile(configFile, data, configFileMode); err != nil {
+		return err
+	}
+	return os.Chmod(configFile, configFileMode)
+}
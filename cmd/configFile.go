@@ -4,6 +4,9 @@ const defaultConfigFile = `# # lc (list cloud) 的云服务商配置文件
 
 # # 配置文件说明
 
+# # （可选）include 用于引入其他配置文件或目录，支持通配符，需要单独写成一个配置，不同文件中的配置 id 不能重复
+# - include: conf.d/*.yaml
+
 # # provider 是云服务商的名字
 # - provider: provider_name
 #   # id 是当前配置文件的名字
@@ -14,15 +17,38 @@ const defaultConfigFile = `# # lc (list cloud) 的云服务商配置文件
 #   secret_key: 
 #   # （可选）session_token 是这个云的访问凭证 session token 部分，仅在访问凭证是临时访问配置时才需要填写这部分的内容
 #   session_token: 
+#   # （可选）credentials_file 是访问凭证文件的路径，文件中使用与这里相同的 access_key、secret_key 和 session_token 字段
+#   credentials_file: 
+#   # （可选）profile 是命令行工具的配置名，未填写访问凭证时会从阿里云 CLI、腾讯云 tccli 或华为云 KooCLI 的配置中读取访问凭证
+#   profile: 
+#   # （可选）regions 是要列出的区域，exclude_regions 是要排除的区域，多个区域使用逗号分隔，以 * 结尾时表示前缀匹配
+#   regions: 
+#   exclude_regions: 
+#   # （可选）<服务名>_endpoint 用于替换服务的默认地址，例如专有云或本地测试环境，地址中的 {region} 会被替换为区域名
+#   ecs_endpoint: 
+#   # （可选）proxy 是访问这个云时使用的代理地址，支持 http 和 socks5 代理，例如 socks5://127.0.0.1:1080
+#   proxy: 
 
 # # 阿里云
 # # 访问凭证获取地址：https://ram.console.aliyun.com
 # - provider: aliyun
 #   id: aliyun_default
-#   cloud_services: ecs,oss,rds,fc,domain
+#   cloud_services: ecs,oss,rds,fc,domain,slb,alb,nlb,eip,alidns,cdn,dcdn,ack,redis,mongodb,polardb,elasticsearch,apigateway,sae,swas,nat
 #   access_key: 
 #   secret_key: 
 #   session_token: 
+#   # （可选）role_arn 是要扮演的 RAM 角色 ARN，填写多个时使用逗号分隔，会依次进行角色链式扮演
+#   role_arn: 
+#   # （可选）role_session_name 是扮演角色时的会话名称，默认为 lc
+#   role_session_name: 
+#   # （可选）external_id 是扮演角色时的外部 ID
+#   external_id: 
+#   # （可选）role_duration 是扮演角色获取的临时访问凭证有效期，单位为秒，默认为 3600
+#   role_duration: 
+#   # （可选）resource_directory 为 true 时会通过资源目录列出所有成员账号，并扮演成员账号中的访问角色列出资产
+#   resource_directory: 
+#   # （可选）resource_directory_role 是成员账号中的访问角色名，默认为 ResourceDirectoryAccountAccessRole
+#   resource_directory_role: 
 
 # # 腾讯云
 # # 访问凭证获取地址：https://console.cloud.tencent.com/cam
@@ -32,6 +58,8 @@ const defaultConfigFile = `# # lc (list cloud) 的云服务商配置文件
 #   access_key: 
 #   secret_key: 
 #   session_token: 
+#   # （可选）role_arn 是要扮演的 CAM 角色 ARN，role_session_name、external_id 和 role_duration 的用法与阿里云相同
+#   role_arn: 
 
 # # 华为云
 # # 访问凭证获取地址：https://console.huaweicloud.com/iam
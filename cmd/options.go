@@ -6,12 +6,15 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 )
 
 type Options struct {
+	Command        string              // Command 要执行的子命令，为空时列出云资产
+	Args           []string            // Args 子命令的参数
 	Threads        int                 // Threads 设置线程数量
 	Silent         bool                // Silent 只展示结果
 	Debug          bool                // Debug 显示详细的输出信息
@@ -22,6 +25,14 @@ type Options struct {
 	Provider       goflags.StringSlice // Provider 指定要列出的云服务商
 	Id             goflags.StringSlice // Id 指定要列出的对象
 	CloudServices  goflags.StringSlice // CloudServices 指定要列出的服务
+	Region         goflags.StringSlice // Region 指定要列出的区域
+	JSON           bool                // JSON 以 JSON 格式输出资产、风险检测结果和子命令的结果
+	Findings       bool                // Findings 输出资产的风险检测结果
+	FindingsOutput string              // FindingsOutput 将风险检测结果写入到文件中
+	Rules          string              // Rules 指定自定义规则文件路径
+	NoDNS          bool                // NoDNS 风险检测时不进行 DNS 查询
+	KeyFile        string              // KeyFile 指定加密配置文件的口令文件路径
+	Proxy          string              // Proxy 指定访问云服务时使用的代理
 }
 
 var (
@@ -30,22 +41,38 @@ var (
 
 func ParseOptions() *Options {
 	options := &Options{}
+	options.parseCommand()
 	flagSet := goflags.NewFlagSet()
-	flagSet.SetDescription(`lc (list cloud) 是一个多云攻击面资产梳理工具`)
+	flagSet.SetDescription(`lc (list cloud) 是一个多云攻击面资产梳理工具
+
+子命令:
+  whoami  查询配置中访问凭证所属的身份信息
+  probe   探测配置中访问凭证对各个服务的访问权限
+  config  管理配置文件，支持 validate、list、add、test、encrypt、decrypt`)
 
 	flagSet.CreateGroup("config", "配置",
-		flagSet.StringVarP(&options.Config, "config", "c", defaultConfigLocation, "指定配置文件路径"),
+		flagSet.StringVarP(&options.Config, "config", "c", defaultConfigLocation, "指定配置文件或配置目录路径"),
+		flagSet.StringVarP(&options.KeyFile, "key-file", "kf", "", "指定加密配置文件的口令文件路径"),
 		flagSet.IntVarP(&options.Threads, "threads", "t", 3, "指定扫描的线程数量"),
+		flagSet.StringVar(&options.Proxy, "proxy", "", "指定访问云服务时使用的代理（支持 http 和 socks5，配置中的 proxy 优先）"),
 	)
 	flagSet.CreateGroup("filter", "过滤",
 		flagSet.StringSliceVarP(&options.CloudServices, "cloud-services", "cs", goflags.StringSlice{"all"}, "指定要列出的服务",
 			goflags.NormalizedStringSliceOptions),
 		flagSet.StringSliceVarP(&options.Id, "id", "i", nil, "指定要使用的配置（以逗号分隔）", goflags.NormalizedStringSliceOptions),
 		flagSet.StringSliceVarP(&options.Provider, "provider", "p", nil, "指定要使用的云服务商（以逗号分隔）", goflags.NormalizedStringSliceOptions),
+		flagSet.StringSliceVarP(&options.Region, "region", "rg", nil, "指定要列出的区域（以逗号分隔，支持 cn-* 形式的前缀匹配）", goflags.NormalizedStringSliceOptions),
 		flagSet.BoolVarP(&options.ExcludePrivate, "exclude-private", "ep", false, "从输出的结果中排除私有 IP"),
 	)
+	flagSet.CreateGroup("findings", "风险检测",
+		flagSet.BoolVarP(&options.Findings, "findings", "f", false, "按风险等级输出资产的风险检测结果"),
+		flagSet.StringVarP(&options.FindingsOutput, "findings-output", "fo", "", "将风险检测结果输出到指定的文件中"),
+		flagSet.StringVarP(&options.Rules, "rules", "r", "", "指定自定义规则文件路径（指定后会自动开启风险检测）"),
+		flagSet.BoolVar(&options.NoDNS, "no-dns", false, "风险检测时不进行 DNS 查询（不检测域名解析是否指向不存在的目标）"),
+	)
 	flagSet.CreateGroup("output", "输出",
 		flagSet.StringVarP(&options.Output, "output", "o", "", "将结果输出到指定的文件中"),
+		flagSet.BoolVar(&options.JSON, "json", false, "以 JSON 格式输出资产、风险检测结果和子命令的结果"),
 		flagSet.BoolVarP(&options.Silent, "silent", "s", false, "只输出结果"),
 		flagSet.BoolVarP(&options.Version, "version", "v", false, "输出工具的版本"),
 		flagSet.BoolVar(&options.Debug, "debug", false, "输出调试日志信息"),
@@ -61,6 +88,18 @@ func ParseOptions() *Options {
 	return options
 }
 
+// parseCommand 从命令行参数中取出位于参数之前的子命令，例如 lc whoami -p aliyun
+func (options *Options) parseCommand() {
+	for len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if options.Command == "" {
+			options.Command = os.Args[1]
+		} else {
+			options.Args = append(options.Args, os.Args[1])
+		}
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+}
+
 func (options *Options) configureOutput() {
 	if options.Silent {
 		gologger.DefaultLogger.SetMaxLevel(levels.LevelSilent)
@@ -80,12 +119,12 @@ func userHomeDir() string {
 
 func checkAndCreateConfigFile(options *Options) {
 	if options.Config == "" || !fileutil.FileExists(defaultConfigLocation) {
-		err := os.MkdirAll(filepath.Dir(options.Config), os.ModePerm)
+		err := os.MkdirAll(filepath.Dir(options.Config), 0700)
 		if err != nil {
 			gologger.Warning().Msgf("无法创建配置文件：%s\n", err)
 		}
 		if !fileutil.FileExists(defaultConfigLocation) {
-			if writeErr := os.WriteFile(defaultConfigLocation, []byte(defaultConfigFile), os.ModePerm); writeErr != nil {
+			if writeErr := os.WriteFile(defaultConfigLocation, []byte(defaultConfigFile), configFileMode); writeErr != nil {
 				gologger.Warning().Msgf("Could not write default output to %s: %s\n", defaultConfigLocation, writeErr)
 			}
 		}
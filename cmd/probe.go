@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/inventory"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+var probeStatusNames = map[string]string{
+	schema.ProbeAllowed:     "允许访问",
+	schema.ProbeDenied:      "无权限",
+	schema.ProbeUnactivated: "服务未开通",
+	schema.ProbeError:       "错误",
+}
+
+// Probe 对每个配置中的云服务发起最小的只读请求，检查访问凭证的权限，不会列出资产
+func (r *Runner) Probe() {
+	for _, block := range r.filterConfig() {
+		provider, _ := block.GetMetadata(utils.Provider)
+		id, _ := block.GetMetadata(utils.Id)
+		results, err := inventory.Probe(block, r.options.CloudServices)
+		if err != nil {
+			gologger.Error().Msgf("无法探测 %s (%s) 的访问权限: %s\n", provider, id, err)
+			continue
+		}
+		for _, result := range results {
+			if r.options.JSON {
+				data, err := json.Marshal(result)
+				if err != nil {
+					continue
+				}
+				gologger.Silent().Msgf("%s", data)
+				continue
+			}
+			status := probeStatusNames[result.Status]
+			if result.Code != "" {
+				status += " (" + result.Code + ")"
+			}
+			gologger.Silent().Msgf("[%s] %s %s: %s", result.ID, result.Provider, result.Service, status)
+			if result.Status == schema.ProbeError {
+				gologger.Debug().Msgf("%s", result.Message)
+			}
+		}
+	}
+}
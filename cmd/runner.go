@@ -3,12 +3,17 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/credentials"
+	"github.com/wgpsec/lc/pkg/findings"
 	"github.com/wgpsec/lc/pkg/inventory"
 	"github.com/wgpsec/lc/pkg/schema"
 	"github.com/wgpsec/lc/utils"
+	"io"
 	"os"
+	"strings"
 )
 
 type Runner struct {
@@ -22,38 +27,86 @@ func New(options *Options) (*Runner, error) {
 		gologger.Print().Msgf("使用默认配置文件: %s\n", options.Config)
 	}
 	checkAndCreateConfigFile(options)
+	utils.ConfigKeyFile = options.KeyFile
 	config, err := utils.ReadConfig(options.Config)
-	if err != nil {
+	// config 子命令需要在配置文件为空时也能使用，例如使用 config add 添加第一个配置
+	if err != nil && !(err == io.EOF && options.Command == "config") {
 		return nil, err
 	}
+	if options.Rules != "" {
+		policyRules, err := findings.LoadPolicyFile(options.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("无法读取规则文件 %s: %s", options.Rules, err)
+		}
+		for _, rule := range policyRules {
+			findings.Register(rule)
+		}
+		gologger.Info().Msgf("从 %s 中加载了 %d 条自定义规则", options.Rules, len(policyRules))
+		options.Findings = true
+	}
 	return &Runner{config: config, options: options}, nil
 }
 
-func (r *Runner) Enumerate() {
-	var (
-		err         error
-		finalConfig schema.Options
-	)
-
-	if r.config, err = utils.ReadConfig(r.options.Config); err != nil {
-		gologger.Fatal().Msgf("程序配置文件无效，请检查后重试，错误：%s", err)
+// Run 执行命令行中指定的子命令
+func (r *Runner) Run() {
+	switch r.options.Command {
+	case "":
+		r.Enumerate()
+	case "whoami":
+		r.WhoAmI()
+	case "probe":
+		r.Probe()
+	case "config":
+		r.Config()
+	default:
+		gologger.Fatal().Msgf("未知的子命令: %s", r.options.Command)
 	}
+}
 
+// filterConfig 根据 -p 和 -i 参数筛选要使用的配置，-region 参数会覆盖配置中的 regions，
+// -proxy 参数会用于没有配置 proxy 且支持代理的配置，然后查找每个配置的访问凭证并展开资源目录中的成员账号
+func (r *Runner) filterConfig() schema.Options {
+	var finalConfig schema.Options
 	for _, item := range r.config {
-		if len(r.options.Provider) != 0 || len(r.options.Id) != 0 {
-			if len(r.options.Provider) != 0 && !utils.Contains(r.options.Provider, item[utils.Provider]) {
-				continue
-			}
-			if len(r.options.Id) != 0 && !utils.Contains(r.options.Id, item[utils.Id]) {
-				continue
+		if len(r.options.Provider) != 0 && !utils.Contains(r.options.Provider, item[utils.Provider]) {
+			continue
+		}
+		if len(r.options.Id) != 0 && !utils.Contains(r.options.Id, item[utils.Id]) {
+			continue
+		}
+		if len(r.options.Region) != 0 {
+			item[utils.Regions] = strings.Join(r.options.Region, ",")
+		}
+		if _, ok := item.GetMetadata(utils.Proxy); !ok && r.options.Proxy != "" {
+			if item[utils.Provider] == utils.TianYi {
+				// 天翼云 OOS 的 SDK 不支持代理，只有在配置中显式指定 proxy 时才报错
+				gologger.Warning().Msgf("天翼云 OOS SDK 不支持设置代理，%s 的请求将不经过 -proxy 指定的代理\n", item[utils.Id])
+			} else {
+				item[utils.Proxy] = r.options.Proxy
 			}
-			finalConfig = append(finalConfig, item)
-		} else {
-			finalConfig = append(finalConfig, item)
 		}
+		if err := credentials.Resolve(item); err != nil {
+			gologger.Error().Msgf("%s\n", err)
+			continue
+		}
+		blocks, err := inventory.Expand(item)
+		if err != nil {
+			gologger.Error().Msgf("无法展开 %s (%s) 的配置: %s\n", item[utils.Provider], item[utils.Id], err)
+			continue
+		}
+		finalConfig = append(finalConfig, blocks...)
+	}
+	return finalConfig
+}
+
+func (r *Runner) Enumerate() {
+	var err error
+
+	if r.config, err = utils.ReadConfig(r.options.Config); err != nil {
+		gologger.Fatal().Msgf("程序配置文件无效，请检查后重试，错误：%s", err)
 	}
 
-	inventory, err := inventory.New(finalConfig, r.options.CloudServices)
+	inventory, err := inventory.New(r.filterConfig(), r.options.CloudServices)
 	if err != nil {
 		gologger.Fatal().Msgf("%s", err)
 	}
@@ -66,6 +119,7 @@ func (r *Runner) Enumerate() {
 		output = outputFile
 	}
 	builder := &bytes.Buffer{}
+	var resources []*schema.Resource
 	schema.SetThreads(r.options.Threads)
 	for _, provider := range inventory.Providers {
 		gologger.Info().Msgf("正在列出 %s (%s) 的资产\n", provider.Name(), provider.ID())
@@ -75,9 +129,26 @@ func (r *Runner) Enumerate() {
 			continue
 		}
 		var Count int
+		resources = append(resources, instances.GetItems()...)
 		for _, instance := range instances.GetItems() {
 			builder.Reset()
-			if instance.DNSName != "" {
+			if r.options.JSON {
+				// JSON 格式每行输出一条完整的资产，包含服务、区域、标签和属性
+				if !instance.Public && r.options.ExcludePrivate {
+					continue
+				}
+				data, err := marshalJSON(instance)
+				if err != nil {
+					continue
+				}
+				Count++
+				builder.Write(data)
+				builder.WriteRune('\n')
+				output.WriteString(builder.String()) //nolint
+				gologger.Silent().Msgf("%s", data)
+				continue
+			}
+			if instance.DNSName != "" && (instance.Public || !r.options.ExcludePrivate) {
 				Count++
 				builder.WriteString(instance.DNSName)
 				builder.WriteRune('\n')
@@ -103,10 +174,52 @@ func (r *Runner) Enumerate() {
 			}
 		}
 		if Count == 0 {
-			gologger.Info().Msgf("在 %s (%s) 下未发现资产，这可能是由于权限不足或没有资产，您可以在确认有相关权限后再进行尝试。", provider.Name(), provider.ID())
+			gologger.Info().Msgf("在 %s (%s) 下未发现资产，这可能是由于权限不足或没有资产，您可以在确认有相关权限后再进行尝试，或使用 lc probe 检查访问凭证对各个服务的权限。", provider.Name(), provider.ID())
 		}
 		if !r.options.Silent {
 			fmt.Println()
 		}
 	}
+	if r.options.Findings {
+		if r.options.NoDNS {
+			findings.SetResolver(nil)
+		}
+		r.outputFindings(findings.Evaluate(resources))
+	}
+}
+
+func (r *Runner) outputFindings(results []*findings.Finding) {
+	var output *os.File
+	if r.options.FindingsOutput != "" {
+		outputFile, err := os.Create(r.options.FindingsOutput)
+		if err != nil {
+			gologger.Fatal().Msgf("无法创建导出的文件 %s: %s\n", r.options.FindingsOutput, err)
+		}
+		defer outputFile.Close()
+		output = outputFile
+	}
+	gologger.Info().Msgf("共发现 %d 条风险检测结果", len(results))
+	for _, finding := range results {
+		line := finding.String()
+		if r.options.JSON {
+			data, err := marshalJSON(finding)
+			if err != nil {
+				continue
+			}
+			line = string(data)
+		}
+		output.WriteString(line + "\n") //nolint
+		gologger.Silent().Msgf("%s", line)
+	}
+}
+
+// marshalJSON 与 json.Marshal 相同，但不转义 <、>、&，使 DNAT 映射等属性中的 -> 保持原样
+func marshalJSON(v any) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
 }
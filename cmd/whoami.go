@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/inventory"
+	"github.com/wgpsec/lc/utils"
+)
+
+// WhoAmI 查询每个配置中访问凭证所属的身份信息，不会列出资产
+func (r *Runner) WhoAmI() {
+	for _, block := range r.filterConfig() {
+		provider, _ := block.GetMetadata(utils.Provider)
+		id, _ := block.GetMetadata(utils.Id)
+		identity, err := inventory.WhoAmI(block)
+		if err != nil {
+			gologger.Error().Msgf("无法获取 %s (%s) 的身份信息: %s\n", provider, id, err)
+			continue
+		}
+		if r.options.JSON {
+			data, err := json.Marshal(identity)
+			if err != nil {
+				continue
+			}
+			gologger.Silent().Msgf("%s", data)
+			continue
+		}
+		keyType := "永久访问凭证"
+		if identity.Temporary {
+			keyType = "临时访问凭证"
+		}
+		gologger.Silent().Msgf("[%s] %s 账号 ID: %s, 用户 ID: %s, ARN: %s, 凭证类型: %s",
+			identity.ID, identity.Provider, orUnknown(identity.AccountID), orUnknown(identity.UserID),
+			orUnknown(identity.Arn), keyType)
+	}
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "未知"
+	}
+	return value
+}
@@ -7,6 +7,7 @@ require (
 	github.com/alibabacloud-go/domain-20180129/v4 v4.2.0
 	github.com/alibabacloud-go/fc-20230330/v4 v4.1.3
 	github.com/alibabacloud-go/fc-open-20210406/v2 v2.0.12
+	github.com/alibabacloud-go/tea v1.2.2
 	github.com/alibabacloud-go/tea-utils/v2 v2.0.6
 	github.com/aliyun/alibaba-cloud-sdk-go v1.62.712
 	github.com/aliyun/aliyun-oss-go-sdk v3.0.2+incompatible
@@ -22,6 +23,8 @@ require (
 	github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm v1.0.893
 	github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/lighthouse v1.0.893
 	github.com/tencentyun/cos-go-sdk-v5 v0.7.47
+	golang.org/x/crypto v0.18.0
+	golang.org/x/term v0.16.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -31,7 +34,6 @@ require (
 	github.com/alibabacloud-go/debug v1.0.0 // indirect
 	github.com/alibabacloud-go/endpoint-util v1.1.0 // indirect
 	github.com/alibabacloud-go/openapi-util v0.1.0 // indirect
-	github.com/alibabacloud-go/tea v1.2.2 // indirect
 	github.com/alibabacloud-go/tea-utils v1.3.1 // indirect
 	github.com/alibabacloud-go/tea-xml v1.1.3 // indirect
 	github.com/aliyun/credentials-go v1.3.1 // indirect
@@ -28,5 +28,5 @@ func main() {
 			gologger.Fatal().Msgf("%s", err)
 		}
 	}
-	runner.Enumerate()
+	runner.Run()
 }
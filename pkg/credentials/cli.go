@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// aliyunCLIProvider 读取阿里云 CLI 的配置文件 ~/.aliyun/config.json
+type aliyunCLIProvider struct{}
+
+type aliyunCLIConfig struct {
+	Current  string `json:"current"`
+	Profiles []struct {
+		Name            string `json:"name"`
+		Mode            string `json:"mode"`
+		AccessKeyID     string `json:"access_key_id"`
+		AccessKeySecret string `json:"access_key_secret"`
+		StsToken        string `json:"sts_token"`
+	} `json:"profiles"`
+}
+
+func (p *aliyunCLIProvider) Name() string {
+	return "阿里云 CLI 配置文件"
+}
+
+func (p *aliyunCLIProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	if provider, _ := block.GetMetadata(utils.Provider); provider != utils.Aliyun {
+		return nil, nil
+	}
+	var config aliyunCLIConfig
+	if ok, err := readJSONFile("~/.aliyun/config.json", &config); !ok || err != nil {
+		return nil, err
+	}
+	name := profileName(block, os.Getenv("ALIBABA_CLOUD_PROFILE"), config.Current)
+	for _, profile := range config.Profiles {
+		if profile.Name != name {
+			continue
+		}
+		switch profile.Mode {
+		case "AK", "StsToken", "":
+			return &Credential{
+				AccessKey:    profile.AccessKeyID,
+				SecretKey:    profile.AccessKeySecret,
+				SessionToken: profile.StsToken,
+			}, nil
+		default:
+			return nil, fmt.Errorf("不支持认证模式为 %s 的配置 %s", profile.Mode, name)
+		}
+	}
+	return nil, nil
+}
+
+// tccliProvider 读取腾讯云 tccli 的凭证文件 ~/.tccli/<profile>.credential
+type tccliProvider struct{}
+
+type tccliCredential struct {
+	SecretID  string `json:"secretId"`
+	SecretKey string `json:"secretKey"`
+	Token     string `json:"token"`
+}
+
+func (p *tccliProvider) Name() string {
+	return "腾讯云 tccli 凭证文件"
+}
+
+func (p *tccliProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	if provider, _ := block.GetMetadata(utils.Provider); provider != utils.Tencent {
+		return nil, nil
+	}
+	var credential tccliCredential
+	name := profileName(block, os.Getenv("TENCENTCLOUD_PROFILE"), "default")
+	if ok, err := readJSONFile(filepath.Join("~/.tccli", name+".credential"), &credential); !ok || err != nil {
+		return nil, err
+	}
+	return &Credential{AccessKey: credential.SecretID, SecretKey: credential.SecretKey, SessionToken: credential.Token}, nil
+}
+
+// hcloudProvider 读取华为云 KooCLI 的配置文件 ~/.hcloud/config.json，仅支持未加密保存的访问凭证
+type hcloudProvider struct{}
+
+type hcloudConfig struct {
+	Current  string `json:"current"`
+	Profiles []struct {
+		Name            string `json:"name"`
+		Mode            string `json:"mode"`
+		AccessKeyID     string `json:"accessKeyId"`
+		SecretAccessKey string `json:"secretAccessKey"`
+		SecurityToken   string `json:"securityToken"`
+	} `json:"profiles"`
+}
+
+func (p *hcloudProvider) Name() string {
+	return "华为云 KooCLI 配置文件"
+}
+
+func (p *hcloudProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	if provider, _ := block.GetMetadata(utils.Provider); provider != utils.Huawei {
+		return nil, nil
+	}
+	var config hcloudConfig
+	if ok, err := readJSONFile("~/.hcloud/config.json", &config); !ok || err != nil {
+		return nil, err
+	}
+	name := profileName(block, "", config.Current)
+	for _, profile := range config.Profiles {
+		if profile.Name == name {
+			return &Credential{
+				AccessKey:    profile.AccessKeyID,
+				SecretKey:    profile.SecretAccessKey,
+				SessionToken: profile.SecurityToken,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// profileName 返回要使用的命令行工具配置名，依次使用配置中的 profile、环境变量和命令行工具当前使用的配置
+func profileName(block schema.OptionBlock, env, current string) string {
+	if profile, ok := block.GetMetadata(utils.Profile); ok {
+		return profile
+	}
+	if env != "" {
+		return env
+	}
+	if current != "" {
+		return current
+	}
+	return "default"
+}
+
+// readJSONFile 读取 JSON 文件，文件不存在时返回 false
+func readJSONFile(path string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// expandHome 将路径开头的 ~ 替换为用户主目录
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,127 @@
+package credentials
+
+import (
+	"fmt"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"gopkg.in/yaml.v3"
+	"os"
+)
+
+// Credential 是从某个来源获取到的访问凭证
+type Credential struct {
+	AccessKey    string `yaml:"access_key"`
+	SecretKey    string `yaml:"secret_key"`
+	SessionToken string `yaml:"session_token"`
+}
+
+// Provider 是访问凭证的来源，Retrieve 在该来源中找不到访问凭证时返回 nil
+type Provider interface {
+	Name() string
+	Retrieve(block schema.OptionBlock) (*Credential, error)
+}
+
+// chain 是查找访问凭证的顺序，配置中直接填写的访问凭证优先级最高
+var chain = []Provider{
+	&staticProvider{},
+	&fileProvider{},
+	&envProvider{},
+	&aliyunCLIProvider{},
+	&tccliProvider{},
+	&hcloudProvider{},
+}
+
+// Resolve 依次从各个来源查找配置的访问凭证，并将找到的访问凭证写入配置中，所有来源都没有找到时保持配置不变
+func Resolve(block schema.OptionBlock) error {
+	provider, _ := block.GetMetadata(utils.Provider)
+	id, _ := block.GetMetadata(utils.Id)
+	for _, source := range chain {
+		credential, err := source.Retrieve(block)
+		if err != nil {
+			return fmt.Errorf("无法从 %s 获取 %s (%s) 的访问凭证: %s", source.Name(), provider, id, err)
+		}
+		if credential == nil || credential.AccessKey == "" || credential.SecretKey == "" {
+			continue
+		}
+		gologger.Debug().Msgf("从 %s 获取到 %s (%s) 的访问凭证", source.Name(), provider, id)
+		block[utils.AccessKey] = credential.AccessKey
+		block[utils.SecretKey] = credential.SecretKey
+		if credential.SessionToken != "" {
+			block[utils.SessionToken] = credential.SessionToken
+		} else {
+			delete(block, utils.SessionToken)
+		}
+		return nil
+	}
+	return nil
+}
+
+// staticProvider 使用配置中直接填写的访问凭证
+type staticProvider struct{}
+
+func (p *staticProvider) Name() string {
+	return "配置文件"
+}
+
+func (p *staticProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	accessKey, _ := block.GetMetadata(utils.AccessKey)
+	secretKey, _ := block.GetMetadata(utils.SecretKey)
+	sessionToken, _ := block.GetMetadata(utils.SessionToken)
+	return &Credential{AccessKey: accessKey, SecretKey: secretKey, SessionToken: sessionToken}, nil
+}
+
+// fileProvider 读取 credentials_file 指定的 YAML 或 JSON 文件，文件中的字段名与配置相同
+type fileProvider struct{}
+
+func (p *fileProvider) Name() string {
+	return "credentials_file"
+}
+
+func (p *fileProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	credentialsFile, ok := block.GetMetadata(utils.CredentialsFile)
+	if !ok {
+		return nil, nil
+	}
+	data, err := os.ReadFile(expandHome(credentialsFile))
+	if err != nil {
+		return nil, err
+	}
+	credential := &Credential{}
+	if err := yaml.Unmarshal(data, credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// envVariables 是各个云服务商 SDK 和命令行工具通用的环境变量，依次为 Key、Secret 和 session token
+var envVariables = map[string][3]string{
+	utils.Aliyun:  {"ALIBABA_CLOUD_ACCESS_KEY_ID", "ALIBABA_CLOUD_ACCESS_KEY_SECRET", "ALIBABA_CLOUD_SECURITY_TOKEN"},
+	utils.Tencent: {"TENCENTCLOUD_SECRET_ID", "TENCENTCLOUD_SECRET_KEY", "TENCENTCLOUD_SESSION_TOKEN"},
+	utils.Huawei:  {"HUAWEICLOUD_SDK_AK", "HUAWEICLOUD_SDK_SK", "HUAWEICLOUD_SDK_SECURITY_TOKEN"},
+	utils.Baidu:   {"BCE_ACCESS_KEY_ID", "BCE_SECRET_ACCESS_KEY", "BCE_SESSION_TOKEN"},
+	utils.QiNiu:   {"QINIU_ACCESS_KEY", "QINIU_SECRET_KEY", ""},
+}
+
+// envProvider 从云服务商约定的环境变量中读取访问凭证
+type envProvider struct{}
+
+func (p *envProvider) Name() string {
+	return "环境变量"
+}
+
+func (p *envProvider) Retrieve(block schema.OptionBlock) (*Credential, error) {
+	provider, _ := block.GetMetadata(utils.Provider)
+	variables, ok := envVariables[provider]
+	if !ok {
+		return nil, nil
+	}
+	credential := &Credential{
+		AccessKey: os.Getenv(variables[0]),
+		SecretKey: os.Getenv(variables[1]),
+	}
+	if variables[2] != "" {
+		credential.SessionToken = os.Getenv(variables[2])
+	}
+	return credential, nil
+}
@@ -0,0 +1,188 @@
+package findings
+
+import (
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Severity int
+
+const (
+	Info Severity = iota
+	Low
+	Medium
+	High
+	Critical
+)
+
+var severityNames = map[Severity]string{
+	Info:     "info",
+	Low:      "low",
+	Medium:   "medium",
+	High:     "high",
+	Critical: "critical",
+}
+
+// severityScores 每个风险等级对资产风险分值的贡献
+var severityScores = map[Severity]int{
+	Info:     0,
+	Low:      10,
+	Medium:   25,
+	High:     50,
+	Critical: 80,
+}
+
+const maxRiskScore = 100
+
+// 检测结果的状态，内置规则只会产生 fail 的结果
+const (
+	StatusFail = "fail"
+	StatusPass = "pass"
+)
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// ParseSeverity 将风险等级名称转换为 Severity
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if strings.EqualFold(name, severityName) {
+			return severity, nil
+		}
+	}
+	return Info, fmt.Errorf("发现无效的风险等级: %s", name)
+}
+
+// Finding 是一条规则在一个资产上的检测结果
+type Finding struct {
+	RuleID      string   `json:"rule_id"`
+	Title       string   `json:"title"`
+	Severity    Severity `json:"severity"`
+	Status      string   `json:"status"`
+	RiskScore   int      `json:"risk_score"`
+	Asset       string   `json:"asset"`
+	Provider    string   `json:"provider"`
+	ID          string   `json:"id,omitempty"`
+	Service     string   `json:"service,omitempty"`
+	Region      string   `json:"region,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Rule 是资产检测规则，Evaluate 在规则不适用于该资产时返回 nil
+type Rule interface {
+	ID() string
+	Evaluate(resource *schema.Resource) *Finding
+}
+
+var (
+	rules   []Rule
+	rulesMu sync.RWMutex
+)
+
+// Register 注册一条检测规则，自定义规则可以通过它加入到检测流程中
+func Register(rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules = append(rules, rule)
+}
+
+// Rules 返回已注册的检测规则
+func Rules() []Rule {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	return append([]Rule(nil), rules...)
+}
+
+// Evaluate 使用已注册的规则检测资产，返回按风险等级和风险分值从高到低排序的结果
+func Evaluate(resources []*schema.Resource) []*Finding {
+	var results []*Finding
+	scores := make(map[string]int)
+	for _, resource := range resources {
+		asset := AssetName(resource)
+		if asset == "" {
+			continue
+		}
+		for _, rule := range Rules() {
+			finding := rule.Evaluate(resource)
+			if finding == nil {
+				continue
+			}
+			finding.RuleID = rule.ID()
+			finding.Asset = asset
+			finding.Provider = resource.Provider
+			finding.ID = resource.ID
+			finding.Service = resource.Service
+			finding.Region = resource.Region
+			if finding.Status == "" {
+				finding.Status = StatusFail
+			}
+			if finding.Status == StatusFail {
+				scores[asset] += severityScores[finding.Severity]
+			}
+			results = append(results, finding)
+		}
+	}
+	for _, finding := range results {
+		finding.RiskScore = min(scores[finding.Asset], maxRiskScore)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Status != results[j].Status {
+			return results[i].Status == StatusFail
+		}
+		if results[i].Severity != results[j].Severity {
+			return results[i].Severity > results[j].Severity
+		}
+		return results[i].RiskScore > results[j].RiskScore
+	})
+	return results
+}
+
+// AssetName 返回资产用于展示的名称
+func AssetName(resource *schema.Resource) string {
+	switch {
+	case resource.DNSName != "":
+		return resource.DNSName
+	case resource.PublicIPv4 != "":
+		return resource.PublicIPv4
+	default:
+		return resource.PrivateIpv4
+	}
+}
+
+func (f *Finding) String() string {
+	builder := &strings.Builder{}
+	builder.WriteString(fmt.Sprintf("[%s] [%s] [%d] %s",
+		strings.ToUpper(f.Severity.String()), strings.ToUpper(f.Status), f.RiskScore, f.Asset))
+	builder.WriteString(fmt.Sprintf(" (%s/%s", f.Provider, f.ID))
+	if f.Service != "" {
+		builder.WriteString("/" + f.Service)
+	}
+	if f.Region != "" {
+		builder.WriteString("/" + f.Region)
+	}
+	builder.WriteString(") " + f.Title)
+	if f.Description != "" {
+		builder.WriteString(": " + f.Description)
+	}
+	return builder.String()
+}
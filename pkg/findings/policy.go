@@ -0,0 +1,143 @@
+package findings
+
+import (
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"gopkg.in/yaml.v3"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// PolicyRule 是在规则文件中声明的自定义规则，对满足 match 条件的资产检查是否满足 assert 条件，
+// 同一个实例的每个 IP 和域名都是单独的资产，会分别产生检测结果
+type PolicyRule struct {
+	RuleID      string          `yaml:"id"`
+	Title       string          `yaml:"title"`
+	Description string          `yaml:"description"`
+	Severity    Severity        `yaml:"severity"`
+	Match       PolicyCondition `yaml:"match"`
+	Assert      PolicyCondition `yaml:"assert"`
+}
+
+// PolicyCondition 中的所有条件都满足时才算匹配，未填写的条件会被忽略
+type PolicyCondition struct {
+	Provider   Patterns            `yaml:"provider"`
+	ID         Patterns            `yaml:"id"`
+	Service    Patterns            `yaml:"service"`
+	Region     Patterns            `yaml:"region"`
+	Name       Patterns            `yaml:"name"`
+	Public     *bool               `yaml:"public"`
+	Tags       map[string]Patterns `yaml:"tags"`
+	Attributes map[string]Patterns `yaml:"attributes"`
+}
+
+// Patterns 是一组通配符表达式，匹配其中任意一个即可，以 ! 开头的表达式表示不能匹配
+type Patterns []string
+
+func (p *Patterns) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*p = Patterns{value.Value}
+		return nil
+	}
+	var patterns []string
+	if err := value.Decode(&patterns); err != nil {
+		return err
+	}
+	*p = patterns
+	return nil
+}
+
+// LoadPolicyFile 读取规则文件中的自定义规则
+func LoadPolicyFile(policyFile string) ([]*PolicyRule, error) {
+	var policyRules []*PolicyRule
+
+	file, err := os.Open(policyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if err := yaml.NewDecoder(file).Decode(&policyRules); err != nil {
+		return nil, err
+	}
+	for _, rule := range policyRules {
+		if rule.RuleID == "" {
+			return nil, fmt.Errorf("规则文件 %s 中存在未填写 id 的规则", policyFile)
+		}
+		if rule.Title == "" {
+			rule.Title = rule.RuleID
+		}
+	}
+	return policyRules, nil
+}
+
+func (r *PolicyRule) ID() string {
+	return r.RuleID
+}
+
+func (r *PolicyRule) Evaluate(resource *schema.Resource) *Finding {
+	if !r.Match.matches(resource) {
+		return nil
+	}
+	status := StatusPass
+	if !r.Assert.matches(resource) {
+		status = StatusFail
+	}
+	return &Finding{
+		Title:       r.Title,
+		Severity:    r.Severity,
+		Status:      status,
+		Description: r.Description,
+	}
+}
+
+func (c *PolicyCondition) matches(resource *schema.Resource) bool {
+	if !c.Provider.match(resource.Provider) || !c.ID.match(resource.ID) ||
+		!c.Service.match(resource.Service) || !c.Region.match(resource.Region) ||
+		!c.Name.match(AssetName(resource)) {
+		return false
+	}
+	if c.Public != nil && *c.Public != resource.Public {
+		return false
+	}
+	for key, patterns := range c.Tags {
+		if !patterns.match(resource.Tags[key]) {
+			return false
+		}
+	}
+	for key, patterns := range c.Attributes {
+		if !patterns.match(resource.Attributes[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Patterns) match(value string) bool {
+	var positive, matched bool
+	value = strings.ToLower(value)
+	for _, pattern := range p {
+		pattern = strings.ToLower(pattern)
+		if strings.HasPrefix(pattern, "!") {
+			if globMatch(pattern[1:], value) {
+				return false
+			}
+			continue
+		}
+		positive = true
+		if globMatch(pattern, value) {
+			matched = true
+		}
+	}
+	return matched || !positive
+}
+
+// globMatch 判断 value 是否匹配通配符表达式，* 匹配任意个字符，? 匹配单个字符
+func globMatch(pattern, value string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("^"+expr+"$", value)
+	return err == nil && matched
+}
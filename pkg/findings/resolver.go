@@ -0,0 +1,51 @@
+package findings
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// HostResolver 用于查询域名的解析结果，net.Resolver 实现了这个接口
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// lookupTimeout 是单次 DNS 查询的超时时间
+const lookupTimeout = 5 * time.Second
+
+var (
+	resolver   HostResolver = net.DefaultResolver
+	resolverMu sync.RWMutex
+	// lookupCache 缓存每个域名是否不存在，同一次运行中每个域名只查询一次
+	lookupCache sync.Map
+)
+
+// SetResolver 替换检测规则使用的 DNS 解析器，设置为 nil 时不进行 DNS 查询，依赖 DNS 查询的规则不会产生结果
+func SetResolver(hostResolver HostResolver) {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	resolver = hostResolver
+	lookupCache = sync.Map{}
+}
+
+// hostNotFound 判断域名是否不存在，未设置解析器或查询失败时返回 false
+func hostNotFound(host string) bool {
+	resolverMu.RLock()
+	defer resolverMu.RUnlock()
+	if resolver == nil {
+		return false
+	}
+	if cached, ok := lookupCache.Load(host); ok {
+		return cached.(bool)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	_, err := resolver.LookupHost(ctx, host)
+	var dnsErr *net.DNSError
+	notFound := err != nil && errors.As(err, &dnsErr) && dnsErr.IsNotFound
+	lookupCache.Store(host, notFound)
+	return notFound
+}
@@ -0,0 +1,147 @@
+package findings
+
+import (
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// managementPorts 常见的远程管理端口
+var managementPorts = map[int]string{
+	22:    "SSH",
+	23:    "Telnet",
+	2375:  "Docker",
+	2376:  "Docker",
+	3389:  "RDP",
+	5900:  "VNC",
+	5985:  "WinRM",
+	5986:  "WinRM",
+	6443:  "Kubernetes API",
+	10250: "Kubelet",
+}
+
+// RuleFunc 可以将普通函数包装为检测规则
+type RuleFunc struct {
+	RuleID string
+	Func   func(resource *schema.Resource) *Finding
+}
+
+func (r *RuleFunc) ID() string {
+	return r.RuleID
+}
+
+func (r *RuleFunc) Evaluate(resource *schema.Resource) *Finding {
+	return r.Func(resource)
+}
+
+func init() {
+	Register(&RuleFunc{RuleID: "public-management-port", Func: publicManagementPort})
+	Register(&RuleFunc{RuleID: "public-bucket", Func: publicBucket})
+	Register(&RuleFunc{RuleID: "anonymous-fc-trigger", Func: anonymousFcTrigger})
+	Register(&RuleFunc{RuleID: "database-open-to-world", Func: databaseOpenToWorld})
+	Register(&RuleFunc{RuleID: "dangling-dns", Func: danglingDNS})
+}
+
+func publicManagementPort(resource *schema.Resource) *Finding {
+	if !resource.Public || resource.PublicIPv4 == "" {
+		return nil
+	}
+	var ports []int
+	for port := range managementPorts {
+		if PortOpen(resource.Attributes[schema.AttrOpenPorts], port) {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	sort.Ints(ports)
+	var exposed []string
+	for _, port := range ports {
+		exposed = append(exposed, fmt.Sprintf("%d(%s)", port, managementPorts[port]))
+	}
+	return &Finding{
+		Title:       "公网 IP 对所有来源开放了管理端口",
+		Severity:    High,
+		Description: strings.Join(exposed, ","),
+	}
+}
+
+func publicBucket(resource *schema.Resource) *Finding {
+	switch resource.Attributes[schema.AttrACL] {
+	case "public-read-write":
+		return &Finding{Title: "存储桶允许公共读写", Severity: Critical}
+	case "public-read":
+		return &Finding{Title: "存储桶允许公共读", Severity: High}
+	}
+	return nil
+}
+
+func anonymousFcTrigger(resource *schema.Resource) *Finding {
+	if !resource.Public || resource.Attributes[schema.AttrAuthType] != "anonymous" {
+		return nil
+	}
+	return &Finding{
+		Title:       "函数计算 HTTP 触发器可匿名访问",
+		Severity:    High,
+		Description: "允许的请求方法: " + resource.Attributes[schema.AttrMethods],
+	}
+}
+
+func databaseOpenToWorld(resource *schema.Resource) *Finding {
+	if !resource.Public {
+		return nil
+	}
+	for _, ip := range strings.Split(resource.Attributes[schema.AttrWhitelist], ",") {
+		ip = strings.TrimSpace(ip)
+		if ip == "0.0.0.0/0" || ip == "0.0.0.0" || ip == "::/0" {
+			return &Finding{
+				Title:       "数据库公网地址的白名单对所有来源开放",
+				Severity:    Critical,
+				Description: "白名单: " + resource.Attributes[schema.AttrWhitelist],
+			}
+		}
+	}
+	return nil
+}
+
+// danglingDNS 检查域名的 CNAME 记录指向的目标是否已经不存在
+func danglingDNS(resource *schema.Resource) *Finding {
+	cname := resource.Attributes[schema.AttrCname]
+	if cname == "" || !hostNotFound(cname) {
+		return nil
+	}
+	return &Finding{
+		Title:       "域名解析指向了不存在的目标，可能存在子域名接管风险",
+		Severity:    High,
+		Description: "CNAME: " + cname,
+	}
+}
+
+// PortOpen 判断端口是否在以逗号分隔的端口列表中，列表中可以包含 1-65535 形式的端口范围
+func PortOpen(ports string, port int) bool {
+	for _, item := range strings.Split(ports, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		start, end, found := strings.Cut(item, "-")
+		if !found {
+			end = start
+		}
+		startPort, err := strconv.Atoi(start)
+		if err != nil {
+			continue
+		}
+		endPort, err := strconv.Atoi(end)
+		if err != nil {
+			continue
+		}
+		if port >= startPort && port <= endPort {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package inventory
 import (
 	"fmt"
 	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/providers/aliyun"
 	"github.com/wgpsec/lc/pkg/providers/baidu"
 	"github.com/wgpsec/lc/pkg/providers/huawei"
@@ -29,6 +30,11 @@ func New(options schema.Options, cs goflags.StringSlice) (*Inventory, error) {
 		}
 		provider, err := nameToProvider(value, block, cs)
 		if err != nil {
+			// 通过资源目录发现的成员账号可能没有创建访问角色，跳过这些账号而不是中断整个流程
+			if accountID, ok := block.GetMetadata(utils.AccountId); ok {
+				gologger.Error().Msgf("无法访问成员账号 %s: %s\n", accountID, err)
+				continue
+			}
 			return nil, err
 		}
 		inventory.Providers = append(inventory.Providers, provider)
@@ -58,3 +64,62 @@ func nameToProvider(value string, block schema.OptionBlock, cs goflags.StringSli
 		return nil, fmt.Errorf("发现无效的云服务商名: %s", value)
 	}
 }
+
+// Expand 展开通过资源目录列出所有成员账号的配置，其他配置原样返回
+func Expand(block schema.OptionBlock) ([]schema.OptionBlock, error) {
+	value, _ := block.GetMetadata(utils.Provider)
+	if value == utils.Aliyun && aliyun.ResourceDirectoryEnabled(block) {
+		return aliyun.ResourceDirectoryAccounts(block)
+	}
+	return []schema.OptionBlock{block}, nil
+}
+
+// WhoAmI 获取配置中访问凭证所属的身份信息
+func WhoAmI(block schema.OptionBlock) (*schema.Identity, error) {
+	value, ok := block.GetMetadata(utils.Provider)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.Provider}
+	}
+	switch value {
+	case utils.Aliyun:
+		return aliyun.WhoAmI(block)
+	case utils.Tencent:
+		return tencent.WhoAmI(block)
+	case utils.Huawei:
+		return huawei.WhoAmI(block)
+	case utils.Baidu:
+		return baidu.WhoAmI(block)
+	case utils.TianYi, utils.LianTong, utils.QiNiu, utils.YiDong:
+		return nil, fmt.Errorf("暂不支持查询 %s 访问凭证的身份信息", value)
+	default:
+		return nil, fmt.Errorf("发现无效的云服务商名: %s", value)
+	}
+}
+
+// Probe 探测配置中的访问凭证对每个云服务的访问权限
+func Probe(block schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	value, ok := block.GetMetadata(utils.Provider)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.Provider}
+	}
+	switch value {
+	case utils.Aliyun:
+		return aliyun.Probe(block, cs)
+	case utils.Tencent:
+		return tencent.Probe(block, cs)
+	case utils.Huawei:
+		return huawei.Probe(block, cs)
+	case utils.TianYi:
+		return tianyi.Probe(block, cs)
+	case utils.Baidu:
+		return baidu.Probe(block, cs)
+	case utils.LianTong:
+		return liantong.Probe(block, cs)
+	case utils.QiNiu:
+		return qiniu.Probe(block, cs)
+	case utils.YiDong:
+		return yidong.Probe(block, cs)
+	default:
+		return nil, fmt.Errorf("发现无效的云服务商名: %s", value)
+	}
+}
@@ -0,0 +1,175 @@
+package aliyun
+
+import (
+	"encoding/json"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"net/url"
+	"strings"
+)
+
+// ackProvider 列出容器服务 Kubernetes 集群的 API Server 地址
+type ackProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+// ackClusters 是 DescribeClustersV1 返回的内容，SDK 没有定义返回的字段
+type ackClusters struct {
+	Clusters []struct {
+		ClusterId      string `json:"cluster_id"`
+		Name           string `json:"name"`
+		CurrentVersion string `json:"current_version"`
+		MasterUrl      string `json:"master_url"`
+		Tags           []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"tags"`
+	} `json:"clusters"`
+	PageInfo struct {
+		TotalCount int `json:"total_count"`
+	} `json:"page_info"`
+}
+
+// ackMasterUrl 是集群 master_url 字段中的 JSON 内容
+type ackMasterUrl struct {
+	ApiServerEndpoint         string `json:"api_server_endpoint"`
+	IntranetApiServerEndpoint string `json:"intranet_api_server_endpoint"`
+}
+
+// ackClusterResource 是 DescribeClusterResources 返回的集群资源
+type ackClusterResource struct {
+	ResourceType string `json:"resource_type"`
+	InstanceId   string `json:"instance_id"`
+}
+
+const ackPageSize = 100
+
+func (a *ackProvider) GetResource() (*schema.Resources, error) {
+	ackList := schema.NewResources()
+	forEachRegion(a.regionFilter.Filter(a.regions), func(region string) {
+		csClient, err := a.config.newCsClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 ACK 集群信息", region)
+		request := cs.CreateDescribeClustersV1Request()
+		request.PageSize = requests.NewInteger(ackPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := csClient.DescribeClustersV1(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 ACK 集群失败: %s", region, err)
+				return
+			}
+			var clusters ackClusters
+			if err := json.Unmarshal(response.GetHttpContentBytes(), &clusters); err != nil {
+				gologger.Debug().Msgf("解析 %s 区域下的 ACK 集群失败: %s", region, err)
+				return
+			}
+			for _, cluster := range clusters.Clusters {
+				var masterUrl ackMasterUrl
+				if cluster.MasterUrl != "" {
+					if err := json.Unmarshal([]byte(cluster.MasterUrl), &masterUrl); err != nil {
+						gologger.Debug().Msgf("解析 %s 集群的 API Server 地址失败: %s", cluster.ClusterId, err)
+					}
+				}
+				tags := make(map[string]string)
+				for _, tag := range cluster.Tags {
+					tags[tag.Key] = tag.Value
+				}
+				attributes := map[string]string{
+					schema.AttrVersion:        cluster.CurrentVersion,
+					schema.AttrLoadBalancerID: a.clusterLoadBalancers(csClient, cluster.ClusterId),
+				}
+				resource := &schema.Resource{
+					ID:         a.id,
+					Provider:   a.provider,
+					Service:    "ack",
+					Region:     region,
+					Tags:       tags,
+					Attributes: attributes,
+				}
+				if host, port := splitEndpointURL(masterUrl.ApiServerEndpoint); host != "" {
+					resource.PublicIPv4 = host
+					resource.Public = true
+					attributes[schema.AttrEndpoint] = masterUrl.ApiServerEndpoint
+					attributes[schema.AttrOpenPorts] = port
+				} else {
+					resource.PrivateIpv4, _ = splitEndpointURL(masterUrl.IntranetApiServerEndpoint)
+					attributes[schema.AttrEndpoint] = masterUrl.IntranetApiServerEndpoint
+				}
+				ackList.Append(resource)
+			}
+			if page*ackPageSize >= clusters.PageInfo.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 ACK 集群数据", region)
+		}
+	})
+	return ackList, nil
+}
+
+// clusterLoadBalancers 返回集群使用的 SLB 实例 ID，以逗号分隔
+func (a *ackProvider) clusterLoadBalancers(csClient *cs.Client, clusterId string) string {
+	request := cs.CreateDescribeClusterResourcesRequest()
+	request.ClusterId = clusterId
+	// 接口返回的是 JSON 数组，SDK 解析返回内容时会报错，但请求成功时仍然可以读取返回内容
+	response, err := csClient.DescribeClusterResources(request)
+	if err != nil && !response.IsSuccess() {
+		gologger.Debug().Msgf("获取 %s 集群的资源失败: %s", clusterId, err)
+		return ""
+	}
+	var resources []ackClusterResource
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &resources); err != nil {
+		gologger.Debug().Msgf("解析 %s 集群的资源失败: %s", clusterId, err)
+		return ""
+	}
+	var loadBalancers []string
+	for _, resource := range resources {
+		if strings.EqualFold(resource.ResourceType, "ALIYUN::SLB::LoadBalancer") {
+			loadBalancers = append(loadBalancers, resource.InstanceId)
+		}
+	}
+	return strings.Join(loadBalancers, ",")
+}
+
+// splitEndpointURL 返回 https://1.1.1.1:6443 形式的地址中的主机和端口
+func splitEndpointURL(endpoint string) (string, string) {
+	if endpoint == "" {
+		return "", ""
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", ""
+	}
+	port := endpointURL.Port()
+	if port == "" && endpointURL.Scheme == "https" {
+		port = "443"
+	}
+	return endpointURL.Hostname(), port
+}
+
+// describeAckRegions 容器服务没有查询区域的接口，使用 ECS 的区域列表
+func describeAckRegions(config providerConfig, region string) ([]string, error) {
+	ecsClient, err := config.newEcsClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := ecsClient.DescribeRegions(ecs.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, ecsRegion := range response.Regions.Region {
+		regions = append(regions, ecsRegion.RegionId)
+	}
+	return regions, nil
+}
@@ -0,0 +1,94 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strconv"
+)
+
+func (l *loadBalancerProvider) GetAlbResource() (*schema.Resources, error) {
+	albList := schema.NewResources()
+	forEachRegion(l.regionFilter.Filter(l.regions), func(region string) {
+		albClient, err := l.config.newAlbClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 ALB 资源信息", region)
+		var loadBalancers []alb.LoadBalancer
+		request := alb.CreateListLoadBalancersRequest()
+		request.AddressType = "Internet"
+		for {
+			response, err := albClient.ListLoadBalancers(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 ALB 失败: %s", region, err)
+				return
+			}
+			loadBalancers = append(loadBalancers, response.LoadBalancers...)
+			if response.NextToken == "" {
+				break
+			}
+			gologger.Debug().Msgf("NextToken 不为空，正在获取下一页数据")
+			request.NextToken = response.NextToken
+		}
+		if len(loadBalancers) == 0 {
+			return
+		}
+		listeners := l.albListeners(albClient, region)
+		for _, loadBalancer := range loadBalancers {
+			tags := make(map[string]string)
+			for _, tag := range loadBalancer.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			albList.Append(&schema.Resource{
+				ID:         l.id,
+				Provider:   l.provider,
+				Service:    "alb",
+				Region:     region,
+				DNSName:    loadBalancer.DNSName,
+				Public:     true,
+				Tags:       tags,
+				Attributes: listenerAttributes(listeners[loadBalancer.LoadBalancerId]),
+			})
+		}
+	})
+	return albList, nil
+}
+
+// albListeners 返回区域下所有 ALB 的监听，按负载均衡 ID 分组
+func (l *loadBalancerProvider) albListeners(albClient *alb.Client, region string) map[string][]lbListener {
+	listeners := make(map[string][]lbListener)
+	request := alb.CreateListListenersRequest()
+	for {
+		response, err := albClient.ListListeners(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s 区域下的 ALB 监听失败: %s", region, err)
+			return listeners
+		}
+		for _, listener := range response.Listeners {
+			listeners[listener.LoadBalancerId] = append(listeners[listener.LoadBalancerId], lbListener{
+				protocol: listener.ListenerProtocol, port: strconv.Itoa(listener.ListenerPort),
+			})
+		}
+		if response.NextToken == "" {
+			return listeners
+		}
+		request.NextToken = response.NextToken
+	}
+}
+
+func describeAlbRegions(config providerConfig, region string) ([]string, error) {
+	albClient, err := config.newAlbClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := albClient.DescribeRegions(alb.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, albRegion := range response.Regions {
+		regions = append(regions, albRegion.RegionId)
+	}
+	return regions, nil
+}
@@ -0,0 +1,112 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strings"
+)
+
+// alidnsRecordTypes 是需要列出的解析记录类型
+var alidnsRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT"}
+
+const (
+	alidnsDomainPageSize = 100
+	alidnsRecordPageSize = 500
+)
+
+type alidnsProvider struct {
+	id           string
+	provider     string
+	alidnsClient *alidns.Client
+}
+
+// GetResource 列出所有托管域名下已启用的解析记录，同一个子域名的多条记录会合并为一条资产。
+// 未开通云解析或没有权限时只输出调试信息，返回已获取到的资产
+func (a *alidnsProvider) GetResource() (*schema.Resources, error) {
+	alidnsList := schema.NewResources()
+	domains, err := a.describeDomains()
+	if err != nil {
+		gologger.Debug().Msgf("获取阿里云云解析的域名列表失败: %s", err)
+	}
+	for _, domain := range domains {
+		var (
+			names   []string
+			records = make(map[string][]alidns.Record)
+		)
+		gologger.Debug().Msgf("正在获取阿里云云解析 %s 的解析记录", domain.DomainName)
+		request := alidns.CreateDescribeDomainRecordsRequest()
+		request.DomainName = domain.DomainName
+		request.PageSize = requests.NewInteger(alidnsRecordPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := a.alidnsClient.DescribeDomainRecords(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 的解析记录失败: %s", domain.DomainName, err)
+				break
+			}
+			for _, record := range response.DomainRecords.Record {
+				if !utils.Contains(alidnsRecordTypes, record.Type) || strings.EqualFold(record.Status, "DISABLE") {
+					continue
+				}
+				name := domain.DomainName
+				if record.RR != "@" {
+					name = record.RR + "." + domain.DomainName
+				}
+				if _, ok := records[name]; !ok {
+					names = append(names, name)
+				}
+				records[name] = append(records[name], record)
+			}
+			if int64(page*alidnsRecordPageSize) >= response.TotalCount {
+				break
+			}
+		}
+		tags := make(map[string]string)
+		for _, tag := range domain.Tags.Tag {
+			tags[tag.Key] = tag.Value
+		}
+		for _, name := range names {
+			attributes := make(map[string]string)
+			var values []string
+			for _, record := range records[name] {
+				values = append(values, record.Type+":"+record.Value)
+				if record.Type == "CNAME" {
+					attributes[schema.AttrCname] = record.Value
+				}
+			}
+			attributes[schema.AttrRecords] = strings.Join(values, ",")
+			alidnsList.Append(&schema.Resource{
+				ID:         a.id,
+				Provider:   a.provider,
+				Service:    "alidns",
+				DNSName:    name,
+				Public:     true,
+				Tags:       tags,
+				Attributes: attributes,
+			})
+		}
+	}
+	return alidnsList, nil
+}
+
+// describeDomains 返回云解析中的所有域名，出错时同时返回已获取到的域名
+func (a *alidnsProvider) describeDomains() ([]alidns.DomainInDescribeDomains, error) {
+	var domains []alidns.DomainInDescribeDomains
+	gologger.Debug().Msg("正在获取阿里云云解析的域名列表")
+	request := alidns.CreateDescribeDomainsRequest()
+	request.PageSize = requests.NewInteger(alidnsDomainPageSize)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		response, err := a.alidnsClient.DescribeDomains(request)
+		if err != nil {
+			return domains, err
+		}
+		domains = append(domains, response.Domains.Domain...)
+		if int64(page*alidnsDomainPageSize) >= response.TotalCount {
+			return domains, nil
+		}
+	}
+}
@@ -2,78 +2,84 @@ package aliyun
 
 import (
 	"context"
-	"fmt"
-	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	domain "github.com/alibabacloud-go/domain-20180129/v4/client"
-	"github.com/alibabacloud-go/tea/tea"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
 	"github.com/wgpsec/lc/utils"
 	"strings"
+	"sync"
 )
 
+// defaultRegion 用于调用不区分区域的接口
+const defaultRegion = "cn-beijing"
+
 type Provider struct {
 	id            string
 	provider      string
+	accountID     string
 	config        providerConfig
-	ossClient     *oss.Client
-	domainClient  *domain.Client
 	ecsRegions    *ecs.DescribeRegionsResponse
 	rdsRegions    *rds.DescribeRegionsResponse
 	fcRegions     []FcRegion
+	slbRegions    []string
+	albRegions    []string
+	nlbRegions    []string
+	vpcRegions    []string
+	ackRegions    []string
+	redisRegions  []string
+	mongoRegions  []string
+	polarRegions  []string
+	esRegions     []string
+	apiRegions    []string
+	saeRegions    []string
+	swasRegions   []string
 	cloudServices []string
 	identity      *sts.GetCallerIdentityResponse
-}
-
-type providerConfig struct {
-	accessKeyID     string
-	accessKeySecret string
-	sessionToken    string
-	okST            bool
+	regionFilter  schema.RegionFilter
 }
 
 func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error) {
 	var (
-		region       = "cn-beijing"
-		ossClient    *oss.Client
-		ecsClient    *ecs.Client
-		rdsClient    *rds.Client
-		stsClient    *sts.Client
-		domainClient *domain.Client
-		err          error
+		region    = defaultRegion
+		ecsClient *ecs.Client
+		rdsClient *rds.Client
+		stsClient *sts.Client
 
 		identity   *sts.GetCallerIdentityResponse
 		ecsRegions *ecs.DescribeRegionsResponse
 		rdsRegions *rds.DescribeRegionsResponse
 		fcRegions  []FcRegion
+		slbRegions []string
+		albRegions []string
+		nlbRegions []string
+		vpcRegions []string
+		ackRegions []string
+
+		redisRegions []string
+		mongoRegions []string
+		polarRegions []string
+		esRegions    []string
+		apiRegions   []string
+		saeRegions   []string
+		swasRegions  []string
 
 		cloudServices []string
+		// services 是区域信息获取成功、需要列出资产的服务
+		services []string
 	)
-	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
-	if !ok {
-		return nil, &utils.ErrNoSuchKey{Name: utils.AccessKey}
-	}
-	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
-	if !ok {
-		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	config, err := newProviderConfig(options)
+	if err != nil {
+		return nil, err
 	}
 	id, _ := options.GetMetadata(utils.Id)
-	sessionToken, okST := options.GetMetadata(utils.SessionToken)
-
-	config := providerConfig{
-		accessKeyID:     accessKeyID,
-		accessKeySecret: accessKeySecret,
-		sessionToken:    sessionToken,
-		okST:            okST,
-	}
-	if okST {
+	accountID, _ := options.GetMetadata(utils.AccountId)
+	if config.role != nil {
+		gologger.Debug().Msg("已通过扮演角色获取阿里云临时访问凭证")
+	} else if config.okST {
 		gologger.Debug().Msg("找到阿里云访问临时访问凭证")
 	} else {
 		gologger.Debug().Msg("找到阿里云访问永久访问凭证")
@@ -88,19 +94,9 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		switch cloudService {
 		case "ecs":
 			// ecs client
-			ecsConfig := sdk.NewConfig()
-			if okST {
-				credential := credentials.NewStsTokenCredential(accessKeyID, accessKeySecret, sessionToken)
-				ecsClient, err = ecs.NewClientWithOptions(region, ecsConfig, credential)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				credential := credentials.NewAccessKeyCredential(accessKeyID, accessKeySecret)
-				ecsClient, err = ecs.NewClientWithOptions(region, ecsConfig, credential)
-				if err != nil {
-					return nil, err
-				}
+			ecsClient, err = config.newEcsClient(region)
+			if err != nil {
+				return nil, err
 			}
 			gologger.Debug().Msg("阿里云 ECS 客户端创建成功")
 			// ecs regions
@@ -109,31 +105,11 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 				return nil, err
 			}
 			gologger.Debug().Msg("阿里云 ECS 区域信息获取成功")
-		case "oss":
-			// oss client
-			ossClient, err = oss.New(fmt.Sprintf("oss-%s.aliyuncs.com", region), accessKeyID, accessKeySecret)
-			if err != nil {
-				return nil, err
-			}
-			if okST {
-				ossClient.Config.SecurityToken = sessionToken
-			}
-			gologger.Debug().Msg("阿里云 OSS 客户端创建成功")
 		case "rds":
 			// rds client
-			rdsConfig := sdk.NewConfig()
-			if okST {
-				credential := credentials.NewStsTokenCredential(accessKeyID, accessKeySecret, sessionToken)
-				rdsClient, err = rds.NewClientWithOptions(region, rdsConfig, credential)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				credential := credentials.NewAccessKeyCredential(accessKeyID, accessKeySecret)
-				rdsClient, err = rds.NewClientWithOptions(region, rdsConfig, credential)
-				if err != nil {
-					return nil, err
-				}
+			rdsClient, err = config.newRdsClient(region)
+			if err != nil {
+				return nil, err
 			}
 			gologger.Debug().Msg("阿里云 RDS 客户端创建成功")
 
@@ -145,20 +121,12 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			gologger.Debug().Msg("阿里云 RDS 区域信息获取成功")
 		case "fc":
 			// sts GetCallerIdentity
-			stsConfig := sdk.NewConfig()
-			if okST {
-				credential := credentials.NewStsTokenCredential(accessKeyID, accessKeySecret, sessionToken)
-				stsClient, err = sts.NewClientWithOptions(region, stsConfig, credential)
-			} else {
-				credential := credentials.NewAccessKeyCredential(accessKeyID, accessKeySecret)
-				stsClient, err = sts.NewClientWithOptions(region, stsConfig, credential)
-			}
+			stsClient, err = config.newStsClient(region)
 			if err != nil {
 				return nil, err
 			}
 
 			stsReq := sts.CreateGetCallerIdentityRequest()
-			stsReq.SetScheme("HTTPS")
 			identity, err = stsClient.GetCallerIdentity(stsReq)
 			if err != nil {
 				return nil, err
@@ -166,28 +134,109 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			gologger.Debug().Msg("阿里云 STS 信息获取成功")
 
 			// fc regions
-			fcRegions, err = GetFcRegions()
+			fcRegions, err = GetFcRegions(config.proxy)
 			if err != nil {
 				return nil, err
 			}
 
 			gologger.Debug().Msgf("阿里云 FC 区域信息获取成功, 共 %d 个\n", len(fcRegions))
 
-		case "domain":
-			// domain client
-			credential := &openapi.Config{AccessKeyId: tea.String(accessKeyID), AccessKeySecret: tea.String(accessKeySecret),
-				SecurityToken: tea.String(sessionToken)}
-			domainClient, err = domain.NewClient(credential)
+		case "slb":
+			slbRegions, err = describeSlbRegions(config, region)
 			if err != nil {
-				return nil, err
-				gologger.Debug().Msg("阿里云 Domain 客户端创建成功")
+				gologger.Debug().Msgf("获取阿里云 SLB 区域信息失败，已跳过该服务: %s", err)
+				continue
 			}
+			gologger.Debug().Msg("阿里云 SLB 区域信息获取成功")
+		case "alb":
+			albRegions, err = describeAlbRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 ALB 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 ALB 区域信息获取成功")
+		case "nlb":
+			nlbRegions, err = describeNlbRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 NLB 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 NLB 区域信息获取成功")
+		case "ack":
+			ackRegions, err = describeAckRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 ACK 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 ACK 区域信息获取成功")
+		case "eip", "nat":
+			if vpcRegions == nil {
+				vpcRegions, err = describeVpcRegions(config, region)
+				if err != nil {
+					gologger.Debug().Msgf("获取阿里云 VPC 区域信息失败，已跳过 %s 服务: %s", cloudService, err)
+					continue
+				}
+				gologger.Debug().Msg("阿里云 VPC 区域信息获取成功")
+			}
+		case "redis":
+			redisRegions, err = describeRedisRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 Redis 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 Redis 区域信息获取成功")
+		case "mongodb":
+			mongoRegions, err = describeMongodbRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 MongoDB 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 MongoDB 区域信息获取成功")
+		case "polardb":
+			polarRegions, err = describePolardbRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 PolarDB 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 PolarDB 区域信息获取成功")
+		case "elasticsearch":
+			esRegions, err = describeElasticsearchRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 Elasticsearch 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 Elasticsearch 区域信息获取成功")
+		case "apigateway":
+			apiRegions, err = describeApiGatewayRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 API 网关区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 API 网关区域信息获取成功")
+		case "sae":
+			saeRegions, err = describeSaeRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 SAE 区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云 SAE 区域信息获取成功")
+		case "swas":
+			swasRegions, err = describeSwasRegions(config, region)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云轻量应用服务器区域信息失败，已跳过该服务: %s", err)
+				continue
+			}
+			gologger.Debug().Msg("阿里云轻量应用服务器区域信息获取成功")
 		}
+		services = append(services, cloudService)
 	}
 	return &Provider{
-		provider: utils.Aliyun, id: id, config: config, identity: identity,
-		ossClient: ossClient, ecsRegions: ecsRegions, rdsRegions: rdsRegions, fcRegions: fcRegions, cloudServices: cloudServices,
-		domainClient: domainClient,
+		provider: utils.Aliyun, id: id, accountID: accountID, config: config, identity: identity,
+		ecsRegions: ecsRegions, rdsRegions: rdsRegions, fcRegions: fcRegions, cloudServices: services,
+		slbRegions: slbRegions, albRegions: albRegions, nlbRegions: nlbRegions, vpcRegions: vpcRegions,
+		ackRegions: ackRegions, redisRegions: redisRegions, mongoRegions: mongoRegions, polarRegions: polarRegions,
+		esRegions: esRegions, apiRegions: apiRegions, saeRegions: saeRegions,
+		swasRegions: swasRegions, regionFilter: options.GetRegionFilter(),
 	}, nil
 }
 
@@ -197,28 +246,39 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 		switch cloudService {
 		case "ecs":
 			// ecs
-			ecsProvider := &instanceProvider{id: p.id, provider: p.provider, ecsRegions: p.ecsRegions, config: p.config}
+			ecsProvider := &instanceProvider{id: p.id, provider: p.provider, ecsRegions: p.ecsRegions, config: p.config,
+				regionFilter: p.regionFilter}
 			ecsList, err := ecsProvider.GetEcsResource(ctx)
-			gologger.Info().Msgf("获取到 %d 条阿里云 ECS 信息", len(ecsList.GetItems()))
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
 			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 ECS 信息", len(ecsList.GetItems()))
 			finalList.Merge(ecsList)
 		case "rds":
 			// rds
-			rdsProvider := &dbInstanceProvider{id: p.id, provider: p.provider, rdsRegions: p.rdsRegions, config: p.config}
+			rdsProvider := &dbInstanceProvider{id: p.id, provider: p.provider, rdsRegions: p.rdsRegions, config: p.config,
+				regionFilter: p.regionFilter}
 			rdsList, err := rdsProvider.GetRdsResource(ctx)
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
 			}
 			gologger.Info().Msgf("获取到 %d 条阿里云 RDS 信息", len(rdsList.GetItems()))
 			finalList.Merge(rdsList)
 		case "oss":
 			// oss
-			ossProvider := &ossProvider{ossClient: p.ossClient, id: p.id, provider: p.provider}
+			// 客户端在采集时才创建，避免资源目录中排在后面的成员账号使用已过期的临时访问凭证
+			ossClient, err := p.config.newOssClient(defaultRegion)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			ossProvider := &ossProvider{ossClient: ossClient, id: p.id, provider: p.provider, config: p.config}
 			buckets, err := ossProvider.GetResource(ctx)
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
 			}
 			gologger.Info().Msgf("获取到 %d 条阿里云 OSS 信息", len(buckets.GetItems()))
 			finalList.Merge(buckets)
@@ -226,36 +286,219 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 			// fc
 			fcProvider := &functionProvider{
 				id: p.id, provider: p.provider, config: p.config,
-				fcRegions: p.fcRegions, identity: p.identity,
+				fcRegions: p.fcRegions, identity: p.identity, regionFilter: p.regionFilter,
 			}
 			fcList, err := fcProvider.GetResource()
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 FC 2.0 资产失败: %s", err)
+				fcList = schema.NewResources()
 			}
 			finalList.Merge(fcList)
 
 			// fc 3.0
 			fc3Provider := &function3Provider{
 				id: p.id, provider: p.provider, config: p.config,
-				fcRegions: p.fcRegions, identity: p.identity,
+				fcRegions: p.fcRegions, identity: p.identity, regionFilter: p.regionFilter,
 			}
 			fc3List, err := fc3Provider.GetResource()
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 FC 3.0 资产失败: %s", err)
+				fc3List = schema.NewResources()
 			}
 			gologger.Info().Msgf("获取到 %d 条阿里云 FC 信息", len(fcList.GetItems())+len(fc3List.GetItems()))
 			finalList.Merge(fc3List)
 		case "domain":
 			// domain
-			domainProvider := &domainProvider{id: p.id, provider: p.provider, domainClient: p.domainClient}
+			domainClient, err := domain.NewClient(p.config.newOpenapiConfig("domain", defaultRegion))
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			domainProvider := &domainProvider{id: p.id, provider: p.provider, domainClient: domainClient}
 			domainList, err := domainProvider.GetResource(ctx)
 			if err != nil {
-				return nil, err
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
 			}
 			gologger.Info().Msgf("获取到 %d 条阿里云 Domain 信息", len(domainList.GetItems()))
 			finalList.Merge(domainList)
+		case "slb":
+			slbProvider := &loadBalancerProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.slbRegions, regionFilter: p.regionFilter}
+			slbList, err := slbProvider.GetSlbResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 SLB 信息", len(slbList.GetItems()))
+			finalList.Merge(slbList)
+		case "alb":
+			albProvider := &loadBalancerProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.albRegions, regionFilter: p.regionFilter}
+			albList, err := albProvider.GetAlbResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 ALB 信息", len(albList.GetItems()))
+			finalList.Merge(albList)
+		case "nlb":
+			nlbProvider := &loadBalancerProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.nlbRegions, regionFilter: p.regionFilter}
+			nlbList, err := nlbProvider.GetNlbResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 NLB 信息", len(nlbList.GetItems()))
+			finalList.Merge(nlbList)
+		case "alidns":
+			alidnsClient, err := p.config.newAlidnsClient(defaultRegion)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			alidnsProvider := &alidnsProvider{id: p.id, provider: p.provider, alidnsClient: alidnsClient}
+			alidnsList, err := alidnsProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云云解析信息", len(alidnsList.GetItems()))
+			finalList.Merge(alidnsList)
+		case "cdn":
+			cdnClient, err := p.config.newCdnClient(defaultRegion)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			cdnProvider := &cdnProvider{id: p.id, provider: p.provider, cdnClient: cdnClient}
+			cdnList, err := cdnProvider.GetCdnResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 CDN 信息", len(cdnList.GetItems()))
+			finalList.Merge(cdnList)
+		case "dcdn":
+			dcdnClient, err := p.config.newDcdnClient(defaultRegion)
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			dcdnProvider := &cdnProvider{id: p.id, provider: p.provider, dcdnClient: dcdnClient}
+			dcdnList, err := dcdnProvider.GetDcdnResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 DCDN 信息", len(dcdnList.GetItems()))
+			finalList.Merge(dcdnList)
+		case "ack":
+			ackProvider := &ackProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.ackRegions, regionFilter: p.regionFilter}
+			ackList, err := ackProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 ACK 信息", len(ackList.GetItems()))
+			finalList.Merge(ackList)
+		case "eip":
+			eipProvider := &eipProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.vpcRegions, regionFilter: p.regionFilter}
+			eipList, err := eipProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 EIP 信息", len(eipList.GetItems()))
+			finalList.Merge(eipList)
+		case "nat":
+			natProvider := &natProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.vpcRegions, regionFilter: p.regionFilter}
+			natList, err := natProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 NAT 网关信息", len(natList.GetItems()))
+			finalList.Merge(natList)
+		case "redis":
+			redisProvider := &databaseProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.redisRegions, regionFilter: p.regionFilter}
+			redisList, err := redisProvider.GetRedisResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 Redis 信息", len(redisList.GetItems()))
+			finalList.Merge(redisList)
+		case "mongodb":
+			mongodbProvider := &databaseProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.mongoRegions, regionFilter: p.regionFilter}
+			mongodbList, err := mongodbProvider.GetMongodbResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 MongoDB 信息", len(mongodbList.GetItems()))
+			finalList.Merge(mongodbList)
+		case "polardb":
+			polardbProvider := &databaseProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.polarRegions, regionFilter: p.regionFilter}
+			polardbList, err := polardbProvider.GetPolardbResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 PolarDB 信息", len(polardbList.GetItems()))
+			finalList.Merge(polardbList)
+		case "elasticsearch":
+			elasticsearchProvider := &databaseProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.esRegions, regionFilter: p.regionFilter}
+			elasticsearchList, err := elasticsearchProvider.GetElasticsearchResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 Elasticsearch 信息", len(elasticsearchList.GetItems()))
+			finalList.Merge(elasticsearchList)
+		case "apigateway":
+			apiGatewayProvider := &apiGatewayProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.apiRegions, regionFilter: p.regionFilter}
+			apiGatewayList, err := apiGatewayProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 API 网关信息", len(apiGatewayList.GetItems()))
+			finalList.Merge(apiGatewayList)
+		case "sae":
+			saeProvider := &saeProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.saeRegions, regionFilter: p.regionFilter}
+			saeList, err := saeProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云 SAE 信息", len(saeList.GetItems()))
+			finalList.Merge(saeList)
+		case "swas":
+			swasProvider := &swasProvider{id: p.id, provider: p.provider, config: p.config,
+				regions: p.swasRegions, regionFilter: p.regionFilter}
+			swasList, err := swasProvider.GetResource()
+			if err != nil {
+				gologger.Debug().Msgf("获取阿里云 %s 资产失败，已跳过该服务: %s", cloudService, err)
+				continue
+			}
+			gologger.Info().Msgf("获取到 %d 条阿里云轻量应用服务器信息", len(swasList.GetItems()))
+			finalList.Merge(swasList)
 		}
 	}
+	if p.accountID != "" {
+		finalList.SetAttribute(schema.AttrAccountID, p.accountID)
+	}
 	return finalList, nil
 }
 
@@ -265,3 +508,24 @@ func (p *Provider) Name() string {
 func (p *Provider) ID() string {
 	return p.id
 }
+
+// forEachRegion 使用多个协程并发处理每个区域
+func forEachRegion(regions []string, fn func(region string)) {
+	var wg sync.WaitGroup
+	threads := schema.GetThreads()
+	taskCh := make(chan string, threads)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for region := range taskCh {
+				fn(region)
+			}
+		}()
+	}
+	for _, region := range regions {
+		taskCh <- region
+	}
+	close(taskCh)
+	wg.Wait()
+}
@@ -0,0 +1,119 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cloudapi"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+const apiGatewayPageSize = 50
+
+// subDomainListeners 是 API 分组默认二级域名支持的协议，二级域名同时支持 HTTP 和 HTTPS 访问
+var subDomainListeners = []lbListener{{protocol: "HTTP", port: "80"}, {protocol: "HTTPS", port: "443"}}
+
+// apiGatewayProvider 列出 API 网关分组的二级域名和绑定的自定义域名
+type apiGatewayProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+func (a *apiGatewayProvider) GetResource() (*schema.Resources, error) {
+	apiGatewayList := schema.NewResources()
+	forEachRegion(a.regionFilter.Filter(a.regions), func(region string) {
+		cloudapiClient, err := a.config.newCloudapiClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 API 网关资源信息", region)
+		request := cloudapi.CreateDescribeApiGroupsRequest()
+		request.PageSize = requests.NewInteger(apiGatewayPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := cloudapiClient.DescribeApiGroups(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 API 分组失败: %s", region, err)
+				return
+			}
+			for _, group := range response.ApiGroupAttributes.ApiGroupAttribute {
+				tags := make(map[string]string)
+				for _, tag := range group.Tags.TagInfo {
+					tags[tag.Key] = tag.Value
+				}
+				if group.SubDomain != "" {
+					apiGatewayList.Append(&schema.Resource{
+						ID:         a.id,
+						Provider:   a.provider,
+						Service:    "apigateway",
+						Region:     region,
+						DNSName:    group.SubDomain,
+						Public:     true,
+						Tags:       tags,
+						Attributes: listenerAttributes(subDomainListeners),
+					})
+				}
+				for _, resource := range a.customDomains(cloudapiClient, region, group.GroupId, group.SubDomain, tags) {
+					apiGatewayList.Append(resource)
+				}
+			}
+			if page*apiGatewayPageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 API 分组数据", region)
+		}
+	})
+	return apiGatewayList, nil
+}
+
+// customDomains 返回 API 分组绑定的自定义域名，上传了证书的域名同时支持 HTTPS 访问
+func (a *apiGatewayProvider) customDomains(cloudapiClient *cloudapi.Client, region, groupId, subDomain string, tags map[string]string) []*schema.Resource {
+	var resources []*schema.Resource
+	request := cloudapi.CreateDescribeApiGroupRequest()
+	request.GroupId = groupId
+	response, err := cloudapiClient.DescribeApiGroup(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s API 分组的自定义域名失败: %s", groupId, err)
+		return nil
+	}
+	for _, domain := range response.CustomDomains.DomainItem {
+		listeners := []lbListener{{protocol: "HTTP", port: "80"}}
+		if domain.CertificateId != "" {
+			listeners = append(listeners, lbListener{protocol: "HTTPS", port: "443"})
+		}
+		attributes := listenerAttributes(listeners)
+		if subDomain != "" {
+			attributes[schema.AttrEndpoint] = subDomain
+		}
+		resources = append(resources, &schema.Resource{
+			ID:         a.id,
+			Provider:   a.provider,
+			Service:    "apigateway",
+			Region:     region,
+			DNSName:    domain.DomainName,
+			Public:     true,
+			Tags:       tags,
+			Attributes: attributes,
+		})
+	}
+	return resources
+}
+
+func describeApiGatewayRegions(config providerConfig, region string) ([]string, error) {
+	cloudapiClient, err := config.newCloudapiClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := cloudapiClient.DescribeRegions(cloudapi.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, cloudapiRegion := range response.Regions.Region {
+		regions = append(regions, cloudapiRegion.RegionId)
+	}
+	return regions, nil
+}
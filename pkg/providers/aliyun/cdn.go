@@ -0,0 +1,104 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cdn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dcdn"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strconv"
+	"strings"
+)
+
+const cdnPageSize = 500
+
+// cdnProvider 列出 CDN 和 DCDN 的加速域名
+type cdnProvider struct {
+	id         string
+	provider   string
+	cdnClient  *cdn.Client
+	dcdnClient *dcdn.Client
+}
+
+// GetCdnResource 列出 CDN 加速域名，未开通 CDN 或没有权限时只输出调试信息，返回已获取到的资产
+func (c *cdnProvider) GetCdnResource() (*schema.Resources, error) {
+	cdnList := schema.NewResources()
+	gologger.Debug().Msg("正在获取阿里云 CDN 加速域名信息")
+	request := cdn.CreateDescribeUserDomainsRequest()
+	request.PageSize = requests.NewInteger(cdnPageSize)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		response, err := c.cdnClient.DescribeUserDomains(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取阿里云 CDN 加速域名失败: %s", err)
+			return cdnList, nil
+		}
+		for _, domain := range response.Domains.PageData {
+			var origins []string
+			for _, source := range domain.Sources.Source {
+				origins = append(origins, formatOrigin(source.Type, source.Content, source.Port))
+			}
+			cdnList.Append(c.newResource("cdn", domain.DomainName, domain.Cname, domain.SslProtocol, origins))
+		}
+		if int64(page*cdnPageSize) >= response.TotalCount {
+			return cdnList, nil
+		}
+	}
+}
+
+// GetDcdnResource 列出 DCDN 加速域名，未开通 DCDN 或没有权限时只输出调试信息，返回已获取到的资产
+func (c *cdnProvider) GetDcdnResource() (*schema.Resources, error) {
+	dcdnList := schema.NewResources()
+	gologger.Debug().Msg("正在获取阿里云 DCDN 加速域名信息")
+	request := dcdn.CreateDescribeDcdnUserDomainsRequest()
+	request.PageSize = requests.NewInteger(cdnPageSize)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		response, err := c.dcdnClient.DescribeDcdnUserDomains(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取阿里云 DCDN 加速域名失败: %s", err)
+			return dcdnList, nil
+		}
+		for _, domain := range response.Domains.PageData {
+			var origins []string
+			for _, source := range domain.Sources.Source {
+				origins = append(origins, formatOrigin(source.Type, source.Content, source.Port))
+			}
+			sslProtocol := domain.SSLProtocol
+			if sslProtocol == "" {
+				sslProtocol = domain.SslProtocol
+			}
+			dcdnList.Append(c.newResource("dcdn", domain.DomainName, domain.Cname, sslProtocol, origins))
+		}
+		if int64(page*cdnPageSize) >= response.TotalCount {
+			return dcdnList, nil
+		}
+	}
+}
+
+func (c *cdnProvider) newResource(service, domainName, cname, sslProtocol string, origins []string) *schema.Resource {
+	attributes := map[string]string{
+		schema.AttrOrigins: strings.Join(origins, ","),
+		schema.AttrHTTPS:   strings.ToLower(sslProtocol),
+	}
+	if cname != "" {
+		attributes[schema.AttrEndpoint] = cname
+	}
+	return &schema.Resource{
+		ID:         c.id,
+		Provider:   c.provider,
+		Service:    service,
+		DNSName:    domainName,
+		Public:     true,
+		Attributes: attributes,
+	}
+}
+
+// formatOrigin 将源站转换为 ipaddr:1.1.1.1:80 的形式，未指定端口时省略端口
+func formatOrigin(sourceType, content string, port int) string {
+	origin := sourceType + ":" + content
+	if port != 0 {
+		origin += ":" + strconv.Itoa(port)
+	}
+	return origin
+}
@@ -0,0 +1,26 @@
+package aliyun
+
+import (
+	"testing"
+)
+
+func TestFormatOrigin(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceType string
+		content    string
+		port       int
+		want       string
+	}{
+		{name: "ip with port", sourceType: "ipaddr", content: "1.1.1.1", port: 80, want: "ipaddr:1.1.1.1:80"},
+		{name: "domain with port", sourceType: "domain", content: "origin.example.com", port: 443, want: "domain:origin.example.com:443"},
+		{name: "oss without port", sourceType: "oss", content: "bucket.oss-cn-hangzhou.aliyuncs.com", want: "oss:bucket.oss-cn-hangzhou.aliyuncs.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatOrigin(tt.sourceType, tt.content, tt.port); got != tt.want {
+				t.Errorf("formatOrigin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
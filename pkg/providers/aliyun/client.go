@@ -0,0 +1,255 @@
+package aliyun
+
+import (
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cdn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cloudapi"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dcdn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/elasticsearch"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/nlb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/polardb"
+	r_kvstore "github.com/aliyun/alibaba-cloud-sdk-go/services/r-kvstore"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/resourcemanager"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sae"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/slb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	swas "github.com/aliyun/alibaba-cloud-sdk-go/services/swas-open"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strings"
+)
+
+// configureClient 配置中指定了服务地址时，使用它替换 SDK 根据区域选择的服务地址，配置了代理时通过代理发送请求
+func (c providerConfig) configureClient(client *sdk.Client, service, region string) {
+	if c.proxy != "" {
+		client.SetHttpProxy(c.proxy)
+		client.SetHttpsProxy(c.proxy)
+	}
+	endpoint, ok := c.endpoints.Get(service, region)
+	if !ok {
+		return
+	}
+	scheme, host := schema.SplitEndpoint(endpoint)
+	client.Domain = host
+	client.GetConfig().Scheme = strings.ToUpper(scheme)
+}
+
+func (c providerConfig) newEcsClient(region string) (*ecs.Client, error) {
+	client, err := ecs.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "ecs", region)
+	return client, nil
+}
+
+func (c providerConfig) newRdsClient(region string) (*rds.Client, error) {
+	client, err := rds.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "rds", region)
+	return client, nil
+}
+
+func (c providerConfig) newStsClient(region string) (*sts.Client, error) {
+	client, err := sts.NewClientWithOptions(region, sdk.NewConfig().WithScheme("HTTPS"), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "sts", region)
+	return client, nil
+}
+
+func (c providerConfig) newResourceManagerClient(region string) (*resourcemanager.Client, error) {
+	client, err := resourcemanager.NewClientWithOptions(region, sdk.NewConfig().WithScheme("HTTPS"), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "resourcemanager", region)
+	return client, nil
+}
+
+func (c providerConfig) newSlbClient(region string) (*slb.Client, error) {
+	client, err := slb.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "slb", region)
+	return client, nil
+}
+
+func (c providerConfig) newAlbClient(region string) (*alb.Client, error) {
+	client, err := alb.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "alb", region)
+	return client, nil
+}
+
+func (c providerConfig) newNlbClient(region string) (*nlb.Client, error) {
+	client, err := nlb.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "nlb", region)
+	return client, nil
+}
+
+func (c providerConfig) newVpcClient(region string) (*vpc.Client, error) {
+	client, err := vpc.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "vpc", region)
+	return client, nil
+}
+
+func (c providerConfig) newAlidnsClient(region string) (*alidns.Client, error) {
+	client, err := alidns.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "alidns", region)
+	return client, nil
+}
+
+func (c providerConfig) newCdnClient(region string) (*cdn.Client, error) {
+	client, err := cdn.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "cdn", region)
+	return client, nil
+}
+
+func (c providerConfig) newDcdnClient(region string) (*dcdn.Client, error) {
+	client, err := dcdn.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "dcdn", region)
+	return client, nil
+}
+
+func (c providerConfig) newCsClient(region string) (*cs.Client, error) {
+	client, err := cs.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "ack", region)
+	return client, nil
+}
+
+func (c providerConfig) newKvstoreClient(region string) (*r_kvstore.Client, error) {
+	client, err := r_kvstore.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "redis", region)
+	return client, nil
+}
+
+func (c providerConfig) newDdsClient(region string) (*dds.Client, error) {
+	client, err := dds.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "mongodb", region)
+	return client, nil
+}
+
+func (c providerConfig) newPolardbClient(region string) (*polardb.Client, error) {
+	client, err := polardb.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "polardb", region)
+	return client, nil
+}
+
+func (c providerConfig) newElasticsearchClient(region string) (*elasticsearch.Client, error) {
+	client, err := elasticsearch.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "elasticsearch", region)
+	return client, nil
+}
+
+func (c providerConfig) newCloudapiClient(region string) (*cloudapi.Client, error) {
+	client, err := cloudapi.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "apigateway", region)
+	return client, nil
+}
+
+func (c providerConfig) newSaeClient(region string) (*sae.Client, error) {
+	client, err := sae.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "sae", region)
+	return client, nil
+}
+
+func (c providerConfig) newSwasClient(region string) (*swas.Client, error) {
+	client, err := swas.NewClientWithOptions(region, sdk.NewConfig(), c.sdkCredential())
+	if err != nil {
+		return nil, err
+	}
+	c.configureClient(&client.Client, "swas", region)
+	return client, nil
+}
+
+// newOssClient 使用当前可用的访问凭证创建指定区域的 OSS 客户端
+func (c providerConfig) newOssClient(region string) (*oss.Client, error) {
+	var options []oss.ClientOption
+	if c.proxy != "" {
+		options = append(options, oss.Proxy(c.proxy))
+	}
+	endpoint := c.endpoints.Resolve("oss", region, "oss-"+region+".aliyuncs.com")
+	c = c.current()
+	client, err := oss.New(endpoint, c.accessKeyID, c.accessKeySecret, options...)
+	if err != nil {
+		return nil, err
+	}
+	if c.okST {
+		client.Config.SecurityToken = c.sessionToken
+	}
+	return client, nil
+}
+
+// newOpenapiConfig 使用当前可用的访问凭证创建 OpenAPI 客户端配置，配置中未指定服务地址时 Endpoint 为空
+func (c providerConfig) newOpenapiConfig(service, region string) *openapi.Config {
+	endpoint, ok := c.endpoints.Get(service, region)
+	proxy := c.proxy
+	c = c.current()
+	config := &openapi.Config{
+		AccessKeyId:     tea.String(c.accessKeyID),
+		AccessKeySecret: tea.String(c.accessKeySecret),
+		SecurityToken:   tea.String(c.sessionToken),
+	}
+	if proxy != "" {
+		config.HttpProxy = tea.String(proxy)
+		config.HttpsProxy = tea.String(proxy)
+	}
+	if ok {
+		scheme, host := schema.SplitEndpoint(endpoint)
+		config.Endpoint = tea.String(host)
+		config.Protocol = tea.String(scheme)
+	}
+	return config
+}
@@ -0,0 +1,177 @@
+package aliyun
+
+import (
+	"fmt"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRoleSessionName = "lc"
+	defaultRoleDuration    = 3600
+	// roleRefreshWindow 临时访问凭证在过期前多久重新扮演角色
+	roleRefreshWindow = 5 * time.Minute
+)
+
+type providerConfig struct {
+	accessKeyID     string
+	accessKeySecret string
+	sessionToken    string
+	okST            bool
+	// role 不为空时访问凭证来自扮演角色，过期前会自动重新扮演角色
+	role *assumedRole
+	// endpoints 是配置中指定的服务地址
+	endpoints schema.Endpoints
+	// proxy 是访问云服务时使用的代理地址
+	proxy string
+}
+
+// assumedRole 保存扮演角色的参数和获取到的临时访问凭证，配置了多个角色时会依次扮演
+type assumedRole struct {
+	mu              sync.Mutex
+	source          providerConfig
+	roleArns        []string
+	roleSessionName string
+	externalID      string
+	duration        int
+	credential      providerConfig
+	expiration      time.Time
+}
+
+// newProviderConfig 读取配置中的访问凭证，配置了 role_arn 时使用扮演角色获取的临时访问凭证
+func newProviderConfig(options schema.OptionBlock) (providerConfig, error) {
+	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
+	if !ok {
+		return providerConfig{}, &utils.ErrNoSuchKey{Name: utils.AccessKey}
+	}
+	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
+	if !ok {
+		return providerConfig{}, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	}
+	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return providerConfig{}, err
+	}
+	config := providerConfig{
+		accessKeyID:     accessKeyID,
+		accessKeySecret: accessKeySecret,
+		sessionToken:    sessionToken,
+		okST:            okST,
+		endpoints:       options.GetEndpoints(),
+		proxy:           proxy,
+	}
+	roleArn, ok := options.GetMetadata(utils.RoleArn)
+	if !ok {
+		return config, nil
+	}
+
+	role := &assumedRole{
+		source:          config,
+		roleSessionName: defaultRoleSessionName,
+		duration:        defaultRoleDuration,
+	}
+	for _, arn := range strings.Split(roleArn, ",") {
+		if arn = strings.TrimSpace(arn); arn != "" {
+			role.roleArns = append(role.roleArns, arn)
+		}
+	}
+	if len(role.roleArns) == 0 {
+		return providerConfig{}, fmt.Errorf("%s 中没有有效的角色 ARN", utils.RoleArn)
+	}
+	if roleSessionName, ok := options.GetMetadata(utils.RoleSessionName); ok {
+		role.roleSessionName = roleSessionName
+	}
+	role.externalID, _ = options.GetMetadata(utils.ExternalId)
+	if duration, ok := options.GetMetadata(utils.RoleDuration); ok {
+		seconds, err := strconv.Atoi(duration)
+		if err != nil {
+			return providerConfig{}, fmt.Errorf("无效的 %s: %s", utils.RoleDuration, duration)
+		}
+		role.duration = seconds
+	}
+	if err := role.assume(); err != nil {
+		return providerConfig{}, err
+	}
+	config = role.credential
+	config.role = role
+	return config, nil
+}
+
+// current 返回当前可用的访问凭证，扮演角色获取的临时访问凭证即将过期时会重新扮演角色
+func (c providerConfig) current() providerConfig {
+	if c.role == nil {
+		return c
+	}
+	return c.role.current()
+}
+
+// sdkCredential 根据是否存在 session token 返回对应的访问凭证
+func (c providerConfig) sdkCredential() auth.Credential {
+	c = c.current()
+	if c.okST {
+		return credentials.NewStsTokenCredential(c.accessKeyID, c.accessKeySecret, c.sessionToken)
+	}
+	return credentials.NewAccessKeyCredential(c.accessKeyID, c.accessKeySecret)
+}
+
+func (r *assumedRole) current() providerConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Until(r.expiration) > roleRefreshWindow {
+		return r.credential
+	}
+	gologger.Debug().Msg("阿里云扮演角色获取的临时访问凭证即将过期，正在重新扮演角色")
+	if err := r.assume(); err != nil {
+		gologger.Warning().Msgf("重新扮演阿里云角色失败: %s", err)
+	}
+	return r.credential
+}
+
+// assume 使用原始访问凭证依次扮演每个角色，后一个角色使用前一个角色的临时访问凭证扮演
+func (r *assumedRole) assume() error {
+	var (
+		config     = r.source
+		expiration time.Time
+	)
+	for _, roleArn := range r.roleArns {
+		stsClient, err := config.newStsClient(defaultRegion)
+		if err != nil {
+			return err
+		}
+		request := sts.CreateAssumeRoleRequest()
+		request.RoleArn = roleArn
+		request.RoleSessionName = r.roleSessionName
+		request.DurationSeconds = requests.NewInteger(r.duration)
+		request.ExternalId = r.externalID
+		response, err := stsClient.AssumeRole(request)
+		if err != nil {
+			return fmt.Errorf("扮演角色 %s 失败: %s", roleArn, err)
+		}
+		gologger.Debug().Msgf("扮演阿里云角色 %s 成功", roleArn)
+		config = providerConfig{
+			accessKeyID:     response.Credentials.AccessKeyId,
+			accessKeySecret: response.Credentials.AccessKeySecret,
+			sessionToken:    response.Credentials.SecurityToken,
+			okST:            true,
+			endpoints:       r.source.endpoints,
+			proxy:           r.source.proxy,
+		}
+		expiration, err = time.Parse(time.RFC3339, response.Credentials.Expiration)
+		if err != nil {
+			expiration = time.Now().Add(time.Duration(r.duration) * time.Second)
+		}
+	}
+	r.credential = config
+	r.expiration = expiration
+	return nil
+}
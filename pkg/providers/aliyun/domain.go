@@ -33,6 +33,7 @@ func (d *domainProvider) GetResource(ctx context.Context) (*schema.Resources, er
 			Public:   true,
 			DNSName:  *domainResult.DomainName,
 			Provider: d.provider,
+			Service:  "domain",
 		})
 	}
 	return domainList, nil
@@ -2,11 +2,10 @@ package aliyun
 
 import (
 	"context"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
+	"strings"
 	"sync"
 )
 
@@ -15,9 +14,12 @@ type instanceProvider struct {
 	provider   string
 	config     providerConfig
 	ecsRegions *ecs.DescribeRegionsResponse
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
 }
 
-var ecsList = schema.NewResources()
+// ecsSecurityGroupPorts 缓存安全组对公网开放的端口，避免同一个安全组被重复查询
+var ecsSecurityGroupPorts = sync.Map{}
 
 func (d *instanceProvider) GetEcsResource(ctx context.Context) (*schema.Resources, error) {
 	var (
@@ -25,18 +27,20 @@ func (d *instanceProvider) GetEcsResource(ctx context.Context) (*schema.Resource
 		err     error
 		wg      sync.WaitGroup
 		regions []string
+		ecsList = schema.NewResources()
 	)
 	threads = schema.GetThreads()
 
 	for _, region := range d.ecsRegions.Regions.Region {
 		regions = append(regions, region.RegionId)
 	}
+	regions = d.regionFilter.Filter(regions)
 
 	taskCh := make(chan string, threads)
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.describeEcsInstances(taskCh, &wg)
+			err = d.describeEcsInstances(taskCh, &wg, ecsList)
 			if err != nil {
 				return
 			}
@@ -50,7 +54,7 @@ func (d *instanceProvider) GetEcsResource(ctx context.Context) (*schema.Resource
 	return ecsList, nil
 }
 
-func (d *instanceProvider) describeEcsInstances(ch <-chan string, wg *sync.WaitGroup) error {
+func (d *instanceProvider) describeEcsInstances(ch <-chan string, wg *sync.WaitGroup, ecsList *schema.Resources) error {
 	defer wg.Done()
 	var (
 		err       error
@@ -58,19 +62,9 @@ func (d *instanceProvider) describeEcsInstances(ch <-chan string, wg *sync.WaitG
 		response  *ecs.DescribeInstancesResponse
 	)
 	for region := range ch {
-		ecsConfig := sdk.NewConfig()
-		if d.config.okST {
-			credential := credentials.NewStsTokenCredential(d.config.accessKeyID, d.config.accessKeySecret, d.config.sessionToken)
-			ecsClient, err = ecs.NewClientWithOptions(region, ecsConfig, credential)
-			if err != nil {
-				continue
-			}
-		} else {
-			credential := credentials.NewAccessKeyCredential(d.config.accessKeyID, d.config.accessKeySecret)
-			ecsClient, err = ecs.NewClientWithOptions(region, ecsConfig, credential)
-			if err != nil {
-				continue
-			}
+		ecsClient, err = d.config.newEcsClient(region)
+		if err != nil {
+			continue
 		}
 		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 ECS 资源信息", region)
 		request := ecs.CreateDescribeInstancesRequest()
@@ -96,23 +90,35 @@ func (d *instanceProvider) describeEcsInstances(ch <-chan string, wg *sync.WaitG
 				if len(instance.NetworkInterfaces.NetworkInterface[0].PrivateIpSets.PrivateIpSet) > 0 {
 					privateIPv4 = instance.NetworkInterfaces.NetworkInterface[0].PrivateIpSets.PrivateIpSet[0].PrivateIpAddress
 				}
+				tags := make(map[string]string)
+				for _, tag := range instance.Tags.Tag {
+					tags[tag.TagKey] = tag.TagValue
+				}
 				if len(ipv4) > 0 {
+					openPorts := d.securityGroupOpenPorts(ecsClient, region, instance.SecurityGroupIds.SecurityGroupId)
 					for _, v := range ipv4 {
 						ecsList.Append(&schema.Resource{
 							ID:          d.id,
 							Provider:    d.provider,
+							Service:     "ecs",
+							Region:      region,
 							PublicIPv4:  v,
 							PrivateIpv4: privateIPv4,
 							Public:      true,
+							Tags:        tags,
+							Attributes:  map[string]string{schema.AttrOpenPorts: openPorts},
 						})
 					}
 				} else {
 					ecsList.Append(&schema.Resource{
 						ID:          d.id,
 						Provider:    d.provider,
+						Service:     "ecs",
+						Region:      region,
 						PublicIPv4:  "",
 						PrivateIpv4: privateIPv4,
 						Public:      false,
+						Tags:        tags,
 					})
 				}
 
@@ -127,3 +133,53 @@ func (d *instanceProvider) describeEcsInstances(ch <-chan string, wg *sync.WaitG
 	}
 	return err
 }
+
+// securityGroupOpenPorts 返回安全组中允许所有来源访问的入方向 TCP 端口
+func (d *instanceProvider) securityGroupOpenPorts(ecsClient *ecs.Client, region string, securityGroupIds []string) string {
+	var ports []string
+	for _, securityGroupId := range securityGroupIds {
+		if cached, ok := ecsSecurityGroupPorts.Load(securityGroupId); ok {
+			ports = append(ports, cached.([]string)...)
+			continue
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云安全组 %s 的规则", region, securityGroupId)
+		request := ecs.CreateDescribeSecurityGroupAttributeRequest()
+		request.RegionId = region
+		request.SecurityGroupId = securityGroupId
+		request.Direction = "ingress"
+		response, err := ecsClient.DescribeSecurityGroupAttribute(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取安全组 %s 的规则失败: %s", securityGroupId, err)
+			continue
+		}
+		var groupPorts []string
+		for _, permission := range response.Permissions.Permission {
+			if permission.Policy != "" && !strings.EqualFold(permission.Policy, "accept") {
+				continue
+			}
+			if permission.SourceCidrIp != "0.0.0.0/0" && permission.Ipv6SourceCidrIp != "::/0" {
+				continue
+			}
+			protocol := strings.ToLower(permission.IpProtocol)
+			if protocol != "tcp" && protocol != "all" {
+				continue
+			}
+			groupPorts = append(groupPorts, normalizePortRange(permission.PortRange))
+		}
+		ecsSecurityGroupPorts.Store(securityGroupId, groupPorts)
+		ports = append(ports, groupPorts...)
+	}
+	return strings.Join(ports, ",")
+}
+
+// normalizePortRange 将阿里云 22/22、-1/-1 形式的端口范围转换为 22、1-65535 的形式
+func normalizePortRange(portRange string) string {
+	start, end, found := strings.Cut(portRange, "/")
+	if !found || start == "-1" || end == "-1" {
+		return "1-65535"
+	}
+	if start == end {
+		return start
+	}
+	return start + "-" + end
+}
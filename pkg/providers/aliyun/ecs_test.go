@@ -0,0 +1,24 @@
+package aliyun
+
+import (
+	"testing"
+)
+
+func TestNormalizePortRange(t *testing.T) {
+	tests := []struct {
+		portRange string
+		want      string
+	}{
+		{portRange: "22/22", want: "22"},
+		{portRange: "1000/2000", want: "1000-2000"},
+		{portRange: "-1/-1", want: "1-65535"},
+		{portRange: "", want: "1-65535"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.portRange, func(t *testing.T) {
+			if got := normalizePortRange(tt.portRange); got != tt.want {
+				t.Errorf("normalizePortRange(%q) = %q, want %q", tt.portRange, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+// eipProvider 列出所有区域下的弹性公网 IP，包括绑定到 NAT 网关、负载均衡、弹性网卡以及未绑定的地址
+type eipProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+const eipPageSize = 100
+
+func (e *eipProvider) GetResource() (*schema.Resources, error) {
+	eipList := schema.NewResources()
+	forEachRegion(e.regionFilter.Filter(e.regions), func(region string) {
+		vpcClient, err := e.config.newVpcClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 EIP 资源信息", region)
+		request := vpc.CreateDescribeEipAddressesRequest()
+		request.PageSize = requests.NewInteger(eipPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := vpcClient.DescribeEipAddresses(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 EIP 失败: %s", region, err)
+				return
+			}
+			for _, eip := range response.EipAddresses.EipAddress {
+				tags := make(map[string]string)
+				for _, tag := range eip.Tags.Tag {
+					tags[tag.Key] = tag.Value
+				}
+				var attributes map[string]string
+				if eip.InstanceId != "" {
+					attributes = map[string]string{
+						schema.AttrInstanceType: eip.InstanceType,
+						schema.AttrInstanceID:   eip.InstanceId,
+					}
+				}
+				eipList.Append(&schema.Resource{
+					ID:         e.id,
+					Provider:   e.provider,
+					Service:    "eip",
+					Region:     region,
+					PublicIPv4: eip.IpAddress,
+					Public:     true,
+					Tags:       tags,
+					Attributes: attributes,
+				})
+			}
+			if page*eipPageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 EIP 数据", region)
+		}
+	})
+	return eipList, nil
+}
+
+func describeVpcRegions(config providerConfig, region string) ([]string, error) {
+	vpcClient, err := config.newVpcClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := vpcClient.DescribeRegions(vpc.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, vpcRegion := range response.Regions.Region {
+		regions = append(regions, vpcRegion.RegionId)
+	}
+	return regions, nil
+}
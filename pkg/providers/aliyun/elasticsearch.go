@@ -0,0 +1,102 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/elasticsearch"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strconv"
+)
+
+func (d *databaseProvider) GetElasticsearchResource() (*schema.Resources, error) {
+	elasticsearchList := schema.NewResources()
+	forEachRegion(d.regionFilter.Filter(d.regions), func(region string) {
+		elasticsearchClient, err := d.config.newElasticsearchClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 Elasticsearch 资源信息", region)
+		request := elasticsearch.CreateListInstanceRequest()
+		request.Size = requests.NewInteger(databasePageSize)
+		for page := 1; ; page++ {
+			request.Page = requests.NewInteger(page)
+			response, err := elasticsearchClient.ListInstance(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 Elasticsearch 实例失败: %s", region, err)
+				return
+			}
+			for _, instance := range response.Result {
+				tags := make(map[string]string)
+				for _, tag := range instance.Tags {
+					tags[tag.TagKey] = tag.TagValue
+				}
+				for _, resource := range d.newElasticsearchResources(elasticsearchClient, region, instance.InstanceId, tags) {
+					elasticsearchList.Append(resource)
+				}
+			}
+			if page*databasePageSize >= response.Headers.XTotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 Elasticsearch 数据", region)
+		}
+	})
+	return elasticsearchList, nil
+}
+
+// newElasticsearchResources 返回 Elasticsearch 实例的访问地址，开启了 Kibana 公网访问时同时返回 Kibana 的公网地址
+func (d *databaseProvider) newElasticsearchResources(elasticsearchClient *elasticsearch.Client, region, instanceId string, tags map[string]string) []*schema.Resource {
+	resource := &schema.Resource{
+		ID:       d.id,
+		Provider: d.provider,
+		Service:  "elasticsearch",
+		Region:   region,
+		Tags:     tags,
+	}
+	request := elasticsearch.CreateDescribeInstanceRequest()
+	request.InstanceId = instanceId
+	response, err := elasticsearchClient.DescribeInstance(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s Elasticsearch 实例的连接信息失败: %s", instanceId, err)
+		return []*schema.Resource{resource}
+	}
+	instance := response.Result
+	if instance.EnablePublic && instance.PublicDomain != "" {
+		resource.PublicIPv4 = instance.PublicDomain
+		resource.Public = true
+		resource.Attributes = databaseAttributes(strconv.Itoa(instance.PublicPort), instance.PublicIpWhitelist)
+		resource.Attributes[schema.AttrVersion] = instance.EsVersion
+	}
+	setIntranetEndpoint(resource, instance.Domain)
+	resources := []*schema.Resource{resource}
+	if instance.EnableKibanaPublicNetwork && instance.KibanaDomain != "" {
+		kibana := &schema.Resource{
+			ID:         d.id,
+			Provider:   d.provider,
+			Service:    "elasticsearch",
+			Region:     region,
+			Tags:       tags,
+			PublicIPv4: instance.KibanaDomain,
+			Public:     true,
+			Attributes: databaseAttributes(strconv.Itoa(instance.KibanaPort), instance.KibanaIPWhitelist),
+		}
+		kibana.Attributes[schema.AttrVersion] = instance.EsVersion
+		resources = append(resources, kibana)
+	}
+	return resources
+}
+
+func describeElasticsearchRegions(config providerConfig, region string) ([]string, error) {
+	elasticsearchClient, err := config.newElasticsearchClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := elasticsearchClient.DescribeRegions(elasticsearch.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, regionInfo := range response.Result {
+		regions = append(regions, regionInfo.RegionId)
+	}
+	return regions, nil
+}
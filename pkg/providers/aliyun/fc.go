@@ -6,11 +6,12 @@ import (
 	"fmt"
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	fc "github.com/alibabacloud-go/fc-open-20210406/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
 	"io"
-	"net/http"
 	"strings"
 	"sync"
 )
@@ -21,6 +22,11 @@ type functionProvider struct {
 	provider  string
 	config    providerConfig
 	fcRegions []FcRegion
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+	// fcList 和 fcResourceMap 只在一次 GetResource 中使用，由 GetResource 创建
+	fcList        *schema.Resources
+	fcResourceMap *sync.Map
 }
 
 type FcRegionsResp struct {
@@ -41,13 +47,48 @@ type FcRegion struct {
 }
 
 type FcTriggerConfig struct {
-	Method             []string `json:"method"`
+	Methods            []string `json:"methods"`
 	AuthType           string   `json:"authType"`
 	DisableURLInternet bool     `json:"disableURLInternet"`
 }
 
-var fcList = schema.NewResources()
-var fcResourceMap = sync.Map{}
+// fcAuthAnonymous 匿名访问的 HTTP 触发器认证方式，其余的还有 function 和 jwt
+const fcAuthAnonymous = "anonymous"
+
+// IsAnonymous 判断 HTTP 触发器是否无需认证即可调用
+func (ftc FcTriggerConfig) IsAnonymous() bool {
+	return ftc.AuthType == "" || strings.EqualFold(ftc.AuthType, fcAuthAnonymous)
+}
+
+// newFcTriggerResource 根据 HTTP 触发器的认证方式生成资产，允许公网匿名访问的触发器会被标记为高风险
+func newFcTriggerResource(id, provider, region, url string, ftc FcTriggerConfig) *schema.Resource {
+	authType := strings.ToLower(ftc.AuthType)
+	if authType == "" {
+		authType = fcAuthAnonymous
+	}
+	risk := schema.RiskLow
+	if ftc.IsAnonymous() && !ftc.DisableURLInternet {
+		risk = schema.RiskHigh
+		gologger.Warning().Msgf("发现可匿名访问的阿里云 FC HTTP 触发器: %s (%s)，允许的请求方法: %s",
+			url, region, strings.Join(ftc.Methods, ","))
+	}
+	return &schema.Resource{
+		ID:       id,
+		Provider: provider,
+		Service:  "fc",
+		Region:   region,
+		// FIXME 目前 lc 输出结果并没有分区一说, 但在 fc 中很难识别是哪个区
+		// 因为控制台鼠标指针放到可用区并不会显示数量.... 所以目前先这样显示
+		// 此外, 有的 url 不会拼接 cn-shanghai 之类的
+		DNSName: fmt.Sprintf("%s#%s", url, region),
+		Public:  !ftc.DisableURLInternet,
+		Attributes: map[string]string{
+			schema.AttrAuthType: authType,
+			schema.AttrMethods:  strings.Join(ftc.Methods, ","),
+			schema.AttrRisk:     risk,
+		},
+	}
+}
 
 func (f *functionProvider) GetResource() (*schema.Resources, error) {
 	var (
@@ -56,9 +97,11 @@ func (f *functionProvider) GetResource() (*schema.Resources, error) {
 		wg      sync.WaitGroup
 		regions []string
 	)
+	f.fcList = schema.NewResources()
+	f.fcResourceMap = &sync.Map{}
 
 	for _, region := range f.fcRegions {
-		if !strings.Contains(region.RegionId, "finance") {
+		if !strings.Contains(region.RegionId, "finance") && f.regionFilter.Allow(region.RegionId) {
 			regions = append(regions, region.RegionId)
 		}
 	}
@@ -96,18 +139,16 @@ func (f *functionProvider) GetResource() (*schema.Resources, error) {
 	close(taskCh)
 	wg.Wait()
 
-	return fcList, nil
+	return f.fcList, nil
 }
 
 func (f *functionProvider) newFcConfig(region string) *openapi.Config {
-	endpoint := fmt.Sprintf("%s.%s.fc.aliyuncs.com", f.identity.AccountId, region)
-	return &openapi.Config{
-		AccessKeyId:     &f.config.accessKeyID,
-		AccessKeySecret: &f.config.accessKeySecret,
-		SecurityToken:   &f.config.sessionToken,
-		Endpoint:        &endpoint,
-		RegionId:        &region,
+	config := f.config.newOpenapiConfig("fc", region)
+	if config.Endpoint == nil {
+		config.Endpoint = tea.String(fmt.Sprintf("%s.%s.fc.aliyuncs.com", f.identity.AccountId, region))
 	}
+	config.RegionId = &region
+	return config
 }
 
 // describeFcCustomDomains 经测试, 就算 fc 禁用公网访问, 如有自定义域名, 能自定义域名+路由直接访问函数
@@ -121,7 +162,7 @@ func (f *functionProvider) describeFcCustomDomains(ch <-chan string, wg *sync.Wa
 
 	for region := range ch {
 
-		if _, ok := fcResourceMap.Load(region); !ok {
+		if _, ok := f.fcResourceMap.Load(region); !ok {
 			gologger.Debug().Msgf("%s 区域下的阿里云无 FC 函数, 跳过获取自定义域名", region)
 			continue
 		}
@@ -142,9 +183,11 @@ func (f *functionProvider) describeFcCustomDomains(ch <-chan string, wg *sync.Wa
 				continue
 			}
 			for _, cd := range domainRes.Body.CustomDomains {
-				fcList.Append(&schema.Resource{
+				f.fcList.Append(&schema.Resource{
 					ID:       f.id,
 					Provider: f.provider,
+					Service:  "fc",
+					Region:   region,
 					// FIXME 目前 lc 输出结果并没有 region 区分, 但在控制台 FC 中很难识别是哪个区
 					// 因为控制台鼠标指针放到可用区并不会显示数量.... 所以目前先这样显示
 					// 此外, 有的 url 不会拼接 cn-shanghai 之类的
@@ -228,7 +271,7 @@ func (f *functionProvider) processFcFunction(fcClient *fc.Client, s *fc.ListServ
 
 		// speed up for describeFcCustomDomains
 		if len(funcRes.Body.Functions) > 0 {
-			fcResourceMap.Store(*fcClient.RegionId, true)
+			f.fcResourceMap.Store(*fcClient.RegionId, true)
 		}
 
 		for _, ft := range funcRes.Body.Functions {
@@ -281,15 +324,7 @@ func (f *functionProvider) processFcTrigger(
 					)
 					continue
 				}
-				fcList.Append(&schema.Resource{
-					ID:       f.id,
-					Provider: f.provider,
-					// FIXME 目前 lc 输出结果并没有分区一说, 但在 fc 中很难识别是哪个区
-					// 因为控制台鼠标指针放到可用区并不会显示数量.... 所以目前先这样显示
-					// 此外, 有的 url 不会拼接 cn-shanghai 之类的
-					DNSName: fmt.Sprintf("%s#%s", *t.UrlInternet, *fcClient.RegionId),
-					Public:  ftc.DisableURLInternet,
-				})
+				f.fcList.Append(newFcTriggerResource(f.id, f.provider, *fcClient.RegionId, *t.UrlInternet, ftc))
 			}
 		}
 		if triggerRes.Body.NextToken == nil {
@@ -306,8 +341,8 @@ func (f *functionProvider) processFcTrigger(
 }
 
 // GetFcRegions 貌似阿里云没有提供 SDK 获取可用区, 只能抓接口拿了
-func GetFcRegions() ([]FcRegion, error) {
-	resp, err := http.Get("https://next.api.aliyun.com/meta/v1/products/FC-Open/endpoints.json")
+func GetFcRegions(proxy string) ([]FcRegion, error) {
+	resp, err := utils.ProxyHTTPClient(proxy).Get("https://next.api.aliyun.com/meta/v1/products/FC-Open/endpoints.json")
 	if err != nil {
 		return nil, errors.New(fmt.Sprintf("Error fetching URL: %v\n", err))
 	}
@@ -1,9 +1,11 @@
 package aliyun
 
 import (
+	"encoding/json"
 	"fmt"
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	fc "github.com/alibabacloud-go/fc-20230330/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
@@ -17,10 +19,12 @@ type function3Provider struct {
 	provider  string
 	config    providerConfig
 	fcRegions []FcRegion
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+	// fc3List 只在一次 GetResource 中使用，由 GetResource 创建
+	fc3List *schema.Resources
 }
 
-var fc3List = schema.NewResources()
-
 func (f *function3Provider) GetResource() (*schema.Resources, error) {
 	var (
 		threads int
@@ -28,9 +32,10 @@ func (f *function3Provider) GetResource() (*schema.Resources, error) {
 		wg      sync.WaitGroup
 		regions []string
 	)
+	f.fc3List = schema.NewResources()
 
 	for _, region := range f.fcRegions {
-		if !strings.Contains(region.RegionId, "finance") {
+		if !strings.Contains(region.RegionId, "finance") && f.regionFilter.Allow(region.RegionId) {
 			regions = append(regions, region.RegionId)
 		}
 	}
@@ -52,18 +57,32 @@ func (f *function3Provider) GetResource() (*schema.Resources, error) {
 	close(taskCh)
 	wg.Wait()
 
-	return fc3List, nil
+	taskCh = make(chan string, threads)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			err = f.listTriggers(taskCh, &wg)
+			if err != nil {
+				return
+			}
+		}()
+	}
+	for _, item := range regions {
+		taskCh <- item
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return f.fc3List, nil
 }
 
 func (f *function3Provider) newFcConfig(region string) *openapi.Config {
-	endpoint := fmt.Sprintf("%s.%s.fc.aliyuncs.com", f.identity.AccountId, region)
-	return &openapi.Config{
-		AccessKeyId:     &f.config.accessKeyID,
-		AccessKeySecret: &f.config.accessKeySecret,
-		SecurityToken:   &f.config.sessionToken,
-		Endpoint:        &endpoint,
-		RegionId:        &region,
+	config := f.config.newOpenapiConfig("fc", region)
+	if config.Endpoint == nil {
+		config.Endpoint = tea.String(fmt.Sprintf("%s.%s.fc.aliyuncs.com", f.identity.AccountId, region))
 	}
+	config.RegionId = &region
+	return config
 }
 
 func (f *function3Provider) listCustomDomains(ch <-chan string, wg *sync.WaitGroup) error {
@@ -90,12 +109,96 @@ func (f *function3Provider) listCustomDomains(ch <-chan string, wg *sync.WaitGro
 			continue
 		}
 		for _, cd := range domainRes.Body.CustomDomains {
-			fc3List.Append(&schema.Resource{
+			f.fc3List.Append(&schema.Resource{
 				ID:       f.id,
 				Provider: f.provider,
+				Service:  "fc",
+				Region:   region,
 				DNSName:  fmt.Sprintf("%s://%s", strings.ToLower(*cd.Protocol), *cd.DomainName),
 			})
 		}
 	}
 	return err
 }
+
+func (f *function3Provider) listTriggers(ch <-chan string, wg *sync.WaitGroup) error {
+	defer wg.Done()
+	var (
+		err      error
+		fcClient *fc.Client
+	)
+
+	for region := range ch {
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 FC 3.0 触发器资源信息", region)
+		fcConfig := f.newFcConfig(region)
+		fcClient, err = fc.NewClient(fcConfig)
+		if err != nil {
+			gologger.Debug().Msgf("%s endpoint NewClient err: %s", *fcConfig.Endpoint, err)
+			break
+		}
+
+		lfReq := &fc.ListFunctionsRequest{}
+		for {
+			funcRes, err := fcClient.ListFunctions(lfReq)
+			if err != nil {
+				gologger.Debug().Msgf("%s endpoint ListFunctions err: %s", *fcClient.Endpoint, err)
+				break
+			}
+			for _, ft := range funcRes.Body.Functions {
+				err = f.processTrigger(fcClient, region, ft)
+				if err != nil {
+					gologger.Debug().Msgf(
+						"%s endpoint [%s] ListTriggers err: %s", *fcClient.Endpoint, *ft.FunctionName, err,
+					)
+				}
+			}
+			if funcRes.Body.NextToken == nil || *funcRes.Body.NextToken == "" {
+				break
+			}
+			gologger.Debug().Msgf("NextToken 不为空，正在获取下一页数据")
+			lfReq.NextToken = funcRes.Body.NextToken
+		}
+	}
+	return err
+}
+
+func (f *function3Provider) processTrigger(fcClient *fc.Client, region string, ft *fc.Function) error {
+	ltReq := &fc.ListTriggersRequest{}
+	for {
+		triggerRes, err := fcClient.ListTriggers(ft.FunctionName, ltReq)
+		if err != nil {
+			return err
+		}
+		for _, t := range triggerRes.Body.Triggers {
+			if t.TriggerType == nil || strings.ToLower(*t.TriggerType) != "http" || t.TriggerConfig == nil {
+				continue
+			}
+			var ftc FcTriggerConfig
+			err = json.Unmarshal([]byte(*t.TriggerConfig), &ftc)
+			if err != nil {
+				gologger.Debug().Msgf("%s endpoint Unmarshal FcTriggerConfig err: %s", *fcClient.Endpoint, err)
+				continue
+			}
+			if ftc.DisableURLInternet {
+				continue
+			}
+			if t.HttpTrigger == nil || t.HttpTrigger.UrlInternet == nil {
+				gologger.Debug().Msgf(
+					"%s endpoint %s enable internet access but url not found, skip",
+					*fcClient.Endpoint, *ft.FunctionName,
+				)
+				continue
+			}
+			f.fc3List.Append(newFcTriggerResource(f.id, f.provider, region, *t.HttpTrigger.UrlInternet, ftc))
+		}
+		if triggerRes.Body.NextToken == nil || *triggerRes.Body.NextToken == "" {
+			break
+		}
+		gologger.Debug().Msgf(
+			"%s function triggerRes NextToken 不为空 %s，正在获取下一页数据",
+			*ft.FunctionName, *triggerRes.Body.NextToken,
+		)
+		ltReq.NextToken = triggerRes.Body.NextToken
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dds"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+// mongodbInstanceTypes 是需要列出的 MongoDB 实例类型，DescribeDBInstances 默认只返回副本集实例
+var mongodbInstanceTypes = []string{"replicate", "sharding"}
+
+func (d *databaseProvider) GetMongodbResource() (*schema.Resources, error) {
+	mongodbList := schema.NewResources()
+	forEachRegion(d.regionFilter.Filter(d.regions), func(region string) {
+		ddsClient, err := d.config.newDdsClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 MongoDB 资源信息", region)
+		for _, instanceType := range mongodbInstanceTypes {
+			request := dds.CreateDescribeDBInstancesRequest()
+			request.DBInstanceType = instanceType
+			request.PageSize = requests.NewInteger(databasePageSize)
+			for page := 1; ; page++ {
+				request.PageNumber = requests.NewInteger(page)
+				response, err := ddsClient.DescribeDBInstances(request)
+				if err != nil {
+					gologger.Debug().Msgf("获取 %s 区域下的 MongoDB 实例失败: %s", region, err)
+					break
+				}
+				for _, instance := range response.DBInstances.DBInstance {
+					tags := make(map[string]string)
+					for _, tag := range instance.Tags.Tag {
+						tags[tag.Key] = tag.Value
+					}
+					mongodbList.Append(d.newMongodbResource(ddsClient, region, instance.DBInstanceId, instanceType, tags))
+				}
+				if page*databasePageSize >= response.TotalCount {
+					break
+				}
+				gologger.Debug().Msgf("正在获取 %s 区域下的下一页 MongoDB 数据", region)
+			}
+		}
+	})
+	return mongodbList, nil
+}
+
+func (d *databaseProvider) newMongodbResource(ddsClient *dds.Client, region, instanceId, instanceType string, tags map[string]string) *schema.Resource {
+	var (
+		publicPort, intranet string
+		resource             = &schema.Resource{
+			ID:       d.id,
+			Provider: d.provider,
+			Service:  "mongodb",
+			Region:   region,
+			Tags:     tags,
+		}
+	)
+	addAddress := func(networkType, address, ip, port string) {
+		if networkType == "Public" {
+			resource.PublicIPv4 = address
+			publicPort = port
+		} else if intranet == "" {
+			intranet = address
+			resource.PrivateIpv4 = ip
+		}
+	}
+	if instanceType == "sharding" {
+		request := dds.CreateDescribeShardingNetworkAddressRequest()
+		request.DBInstanceId = instanceId
+		response, err := ddsClient.DescribeShardingNetworkAddress(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s MongoDB 实例的连接信息失败: %s", instanceId, err)
+			return resource
+		}
+		for _, address := range response.NetworkAddresses.NetworkAddress {
+			addAddress(address.NetworkType, address.NetworkAddress, address.IPAddress, address.Port)
+		}
+	} else {
+		request := dds.CreateDescribeReplicaSetRoleRequest()
+		request.DBInstanceId = instanceId
+		response, err := ddsClient.DescribeReplicaSetRole(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s MongoDB 实例的连接信息失败: %s", instanceId, err)
+			return resource
+		}
+		for _, replicaSet := range response.ReplicaSets.ReplicaSet {
+			addAddress(replicaSet.NetworkType, replicaSet.ConnectionDomain, "", replicaSet.ConnectionPort)
+		}
+	}
+	if resource.PublicIPv4 == "" {
+		setIntranetEndpoint(resource, intranet)
+		return resource
+	}
+	resource.Public = true
+
+	var ips []string
+	ipsRequest := dds.CreateDescribeSecurityIpsRequest()
+	ipsRequest.DBInstanceId = instanceId
+	ipsResponse, err := ddsClient.DescribeSecurityIps(ipsRequest)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s MongoDB 实例的白名单失败: %s", instanceId, err)
+	} else {
+		for _, group := range ipsResponse.SecurityIpGroups.SecurityIpGroup {
+			ips = append(ips, group.SecurityIpList)
+		}
+	}
+	resource.Attributes = databaseAttributes(publicPort, ips)
+	setIntranetEndpoint(resource, intranet)
+	return resource
+}
+
+func describeMongodbRegions(config providerConfig, region string) ([]string, error) {
+	ddsClient, err := config.newDdsClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := ddsClient.DescribeRegions(dds.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, ddsRegion := range response.Regions.DdsRegion {
+		regions = append(regions, ddsRegion.RegionId)
+	}
+	return regions, nil
+}
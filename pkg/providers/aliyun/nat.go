@@ -0,0 +1,163 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strings"
+)
+
+const natPageSize = 50
+
+// natProvider 列出 NAT 网关的公网 IP，以及通过 DNAT 规则映射到内网主机的端口
+type natProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+// dnatEntry 是一条 DNAT 规则，端口使用 80、1000-2000 的形式
+type dnatEntry struct {
+	protocol     string
+	externalIp   string
+	externalPort string
+	internalIp   string
+	internalPort string
+}
+
+func (n *natProvider) GetResource() (*schema.Resources, error) {
+	natList := schema.NewResources()
+	forEachRegion(n.regionFilter.Filter(n.regions), func(region string) {
+		vpcClient, err := n.config.newVpcClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 NAT 网关资源信息", region)
+		request := vpc.CreateDescribeNatGatewaysRequest()
+		request.PageSize = requests.NewInteger(natPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := vpcClient.DescribeNatGateways(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 NAT 网关失败: %s", region, err)
+				return
+			}
+			for _, natGateway := range response.NatGateways.NatGateway {
+				tags := make(map[string]string)
+				for _, tag := range natGateway.Tags.Tag {
+					tags[tag.Key] = tag.Value
+				}
+				var entries []dnatEntry
+				for _, forwardTableId := range natGateway.ForwardTableIds.ForwardTableId {
+					entries = append(entries, n.dnatEntries(vpcClient, forwardTableId)...)
+				}
+				var gatewayIps []string
+				for _, ip := range natGateway.IpLists.IpList {
+					gatewayIps = append(gatewayIps, ip.IpAddress)
+				}
+				for _, ip := range natPublicIps(gatewayIps, entries) {
+					natList.Append(&schema.Resource{
+						ID:         n.id,
+						Provider:   n.provider,
+						Service:    "nat",
+						Region:     region,
+						PublicIPv4: ip,
+						Public:     true,
+						Tags:       tags,
+						Attributes: dnatAttributes(natGateway.NatGatewayId, ip, entries),
+					})
+				}
+			}
+			if page*natPageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 NAT 网关数据", region)
+		}
+	})
+	return natList, nil
+}
+
+// dnatEntries 返回 DNAT 表中的所有规则
+func (n *natProvider) dnatEntries(vpcClient *vpc.Client, forwardTableId string) []dnatEntry {
+	var entries []dnatEntry
+	request := vpc.CreateDescribeForwardTableEntriesRequest()
+	request.ForwardTableId = forwardTableId
+	request.PageSize = requests.NewInteger(natPageSize)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		response, err := vpcClient.DescribeForwardTableEntries(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 DNAT 表 %s 的规则失败: %s", forwardTableId, err)
+			return entries
+		}
+		for _, entry := range response.ForwardTableEntries.ForwardTableEntry {
+			entries = append(entries, dnatEntry{
+				protocol:     strings.ToUpper(entry.IpProtocol),
+				externalIp:   entry.ExternalIp,
+				externalPort: dnatPort(entry.ExternalPort),
+				internalIp:   entry.InternalIp,
+				internalPort: dnatPort(entry.InternalPort),
+			})
+		}
+		if page*natPageSize >= response.TotalCount {
+			return entries
+		}
+	}
+}
+
+// natPublicIps 返回 NAT 网关的公网 IP 以及 DNAT 规则中使用的公网 IP，DNAT 规则使用的 EIP 可能是在列出网关之后才绑定的，
+// 不一定出现在网关的 IP 列表中
+func natPublicIps(gatewayIps []string, entries []dnatEntry) []string {
+	var ips []string
+	for _, ip := range gatewayIps {
+		if ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	for _, entry := range entries {
+		if entry.externalIp != "" {
+			ips = append(ips, entry.externalIp)
+		}
+	}
+	return utils.RemoveRepeatedElement(ips)
+}
+
+// dnatAttributes 返回公网 IP 上的 DNAT 映射，以及映射后对公网开放的 TCP 端口
+func dnatAttributes(natGatewayId, ip string, entries []dnatEntry) map[string]string {
+	var ports, mappings []string
+	for _, entry := range entries {
+		if entry.externalIp != ip {
+			continue
+		}
+		mappings = append(mappings, entry.protocol+":"+entry.externalIp+":"+entry.externalPort+
+			"->"+entry.internalIp+":"+entry.internalPort)
+		if entry.protocol == "TCP" || entry.protocol == "ANY" {
+			ports = append(ports, entry.externalPort)
+		}
+	}
+	attributes := map[string]string{
+		schema.AttrInstanceType: "Nat",
+		schema.AttrInstanceID:   natGatewayId,
+	}
+	if len(mappings) > 0 {
+		attributes[schema.AttrDNAT] = strings.Join(mappings, ",")
+		attributes[schema.AttrOpenPorts] = strings.Join(ports, ",")
+	}
+	return attributes
+}
+
+// dnatPort 将 DNAT 规则中 Any、1000/2000 形式的端口转换为 1-65535、1000-2000 的形式
+func dnatPort(port string) string {
+	if strings.EqualFold(port, "any") {
+		return "1-65535"
+	}
+	if strings.Contains(port, "/") {
+		return normalizePortRange(port)
+	}
+	return port
+}
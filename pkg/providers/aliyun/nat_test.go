@@ -0,0 +1,100 @@
+package aliyun
+
+import (
+	"github.com/wgpsec/lc/pkg/schema"
+	"reflect"
+	"testing"
+)
+
+var testDnatEntries = []dnatEntry{
+	{protocol: "TCP", externalIp: "47.9.9.9", externalPort: "2222", internalIp: "10.0.0.5", internalPort: "22"},
+	{protocol: "UDP", externalIp: "47.9.9.9", externalPort: "53", internalIp: "10.0.0.6", internalPort: "53"},
+	{protocol: "ANY", externalIp: "47.9.9.7", externalPort: "1-65535", internalIp: "10.0.0.7", internalPort: "1-65535"},
+}
+
+func TestNatPublicIps(t *testing.T) {
+	tests := []struct {
+		name       string
+		gatewayIps []string
+		entries    []dnatEntry
+		want       []string
+	}{
+		{name: "gateway ips only", gatewayIps: []string{"47.9.9.9", "", "47.9.9.8"}, want: []string{"47.9.9.9", "47.9.9.8"}},
+		{
+			name:       "entry on an ip missing from the gateway",
+			gatewayIps: []string{"47.9.9.8"},
+			entries:    testDnatEntries,
+			want:       []string{"47.9.9.8", "47.9.9.9", "47.9.9.7"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := natPublicIps(tt.gatewayIps, tt.entries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("natPublicIps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDnatAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want map[string]string
+	}{
+		{
+			name: "tcp and udp mappings",
+			ip:   "47.9.9.9",
+			want: map[string]string{
+				schema.AttrInstanceType: "Nat",
+				schema.AttrInstanceID:   "ngw-1",
+				schema.AttrDNAT:         "TCP:47.9.9.9:2222->10.0.0.5:22,UDP:47.9.9.9:53->10.0.0.6:53",
+				schema.AttrOpenPorts:    "2222",
+			},
+		},
+		{
+			name: "any protocol",
+			ip:   "47.9.9.7",
+			want: map[string]string{
+				schema.AttrInstanceType: "Nat",
+				schema.AttrInstanceID:   "ngw-1",
+				schema.AttrDNAT:         "ANY:47.9.9.7:1-65535->10.0.0.7:1-65535",
+				schema.AttrOpenPorts:    "1-65535",
+			},
+		},
+		{
+			name: "no mappings",
+			ip:   "47.9.9.8",
+			want: map[string]string{
+				schema.AttrInstanceType: "Nat",
+				schema.AttrInstanceID:   "ngw-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dnatAttributes("ngw-1", tt.ip, testDnatEntries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dnatAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDnatPort(t *testing.T) {
+	tests := []struct {
+		port string
+		want string
+	}{
+		{port: "22", want: "22"},
+		{port: "Any", want: "1-65535"},
+		{port: "1000/2000", want: "1000-2000"},
+		{port: "80/80", want: "80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			if got := dnatPort(tt.port); got != tt.want {
+				t.Errorf("dnatPort(%q) = %q, want %q", tt.port, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/nlb"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strconv"
+)
+
+func (l *loadBalancerProvider) GetNlbResource() (*schema.Resources, error) {
+	nlbList := schema.NewResources()
+	forEachRegion(l.regionFilter.Filter(l.regions), func(region string) {
+		nlbClient, err := l.config.newNlbClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 NLB 资源信息", region)
+		var loadBalancers []nlb.LoadbalancerInfo
+		request := nlb.CreateListLoadBalancersRequest()
+		request.AddressType = "Internet"
+		for {
+			response, err := nlbClient.ListLoadBalancers(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 NLB 失败: %s", region, err)
+				return
+			}
+			loadBalancers = append(loadBalancers, response.LoadBalancers...)
+			if response.NextToken == "" {
+				break
+			}
+			gologger.Debug().Msgf("NextToken 不为空，正在获取下一页数据")
+			request.NextToken = response.NextToken
+		}
+		if len(loadBalancers) == 0 {
+			return
+		}
+		listeners := l.nlbListeners(nlbClient, region)
+		for _, loadBalancer := range loadBalancers {
+			tags := make(map[string]string)
+			for _, tag := range loadBalancer.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			attributes := listenerAttributes(listeners[loadBalancer.LoadBalancerId])
+			nlbList.Append(&schema.Resource{
+				ID:         l.id,
+				Provider:   l.provider,
+				Service:    "nlb",
+				Region:     region,
+				DNSName:    loadBalancer.DNSName,
+				Public:     true,
+				Tags:       tags,
+				Attributes: attributes,
+			})
+			for _, zoneMapping := range loadBalancer.ZoneMappings {
+				for _, address := range zoneMapping.LoadBalancerAddresses {
+					nlbList.Append(&schema.Resource{
+						ID:          l.id,
+						Provider:    l.provider,
+						Service:     "nlb",
+						Region:      region,
+						PublicIPv4:  address.PublicIPv4Address,
+						PrivateIpv4: address.PrivateIPv4Address,
+						Public:      address.PublicIPv4Address != "",
+						Tags:        tags,
+						Attributes:  attributes,
+					})
+				}
+			}
+		}
+	})
+	return nlbList, nil
+}
+
+// nlbListeners 返回区域下所有 NLB 的监听，按负载均衡 ID 分组，多端口监听使用端口范围表示
+func (l *loadBalancerProvider) nlbListeners(nlbClient *nlb.Client, region string) map[string][]lbListener {
+	listeners := make(map[string][]lbListener)
+	request := nlb.CreateListListenersRequest()
+	for {
+		response, err := nlbClient.ListListeners(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s 区域下的 NLB 监听失败: %s", region, err)
+			return listeners
+		}
+		for _, listener := range response.Listeners {
+			port := strconv.Itoa(listener.ListenerPort)
+			if listener.ListenerPort == 0 && listener.StartPort != "" {
+				port = listener.StartPort + "-" + listener.EndPort
+			}
+			listeners[listener.LoadBalancerId] = append(listeners[listener.LoadBalancerId], lbListener{
+				protocol: listener.ListenerProtocol, port: port,
+			})
+		}
+		if response.NextToken == "" {
+			return listeners
+		}
+		request.NextToken = response.NextToken
+	}
+}
+
+func describeNlbRegions(config providerConfig, region string) ([]string, error) {
+	nlbClient, err := config.newNlbClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := nlbClient.DescribeRegions(nlb.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, nlbRegion := range response.Regions {
+		regions = append(regions, nlbRegion.RegionId)
+	}
+	return regions, nil
+}
@@ -11,11 +11,13 @@ import (
 type ossProvider struct {
 	id        string
 	provider  string
+	config    providerConfig
 	ossClient *oss.Client
 }
 
 func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error) {
 	ossList := schema.NewResources()
+	regionClients := make(map[string]*oss.Client)
 	marker := oss.Marker("")
 	gologger.Debug().Msg("正在获取阿里云 OSS 资源信息")
 	for {
@@ -25,16 +27,7 @@ func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error
 		}
 		marker = oss.Marker(response.NextMarker)
 		for _, bucket := range response.Buckets {
-			endpointBuilder := &strings.Builder{}
-			endpointBuilder.WriteString(bucket.Name)
-			endpointBuilder.WriteString(".oss-" + bucket.Region)
-			endpointBuilder.WriteString(".aliyuncs.com")
-			ossList.Append(&schema.Resource{
-				ID:       d.id,
-				Public:   true,
-				DNSName:  endpointBuilder.String(),
-				Provider: d.provider,
-			})
+			d.appendBucket(ossList, d.bucketClient(regionClients, bucket), bucket)
 		}
 		if !response.IsTruncated {
 			break
@@ -42,3 +35,107 @@ func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error
 	}
 	return ossList, nil
 }
+
+// appendBucket 添加存储桶的外网地址、传输加速地址、绑定的自定义域名以及只能在内网访问的内网地址
+func (d *ossProvider) appendBucket(ossList *schema.Resources, client *oss.Client, bucket oss.BucketProperties) {
+	endpoint := bucket.Name + ".oss-" + bucket.Region + ".aliyuncs.com"
+	attributes := map[string]string{schema.AttrACL: d.getBucketACL(client, bucket)}
+	resources := []*schema.Resource{d.newBucketResource(bucket, endpoint, attributes)}
+	var domains []string
+	if client != nil {
+		if transferAcc, err := client.GetBucketTransferAcc(bucket.Name); err != nil {
+			gologger.Debug().Msgf("获取 %s 存储桶的传输加速配置失败: %s", bucket.Name, err)
+		} else if transferAcc.Enabled {
+			resources = append(resources, d.newBucketResource(bucket, bucket.Name+".oss-accelerate.aliyuncs.com", attributes))
+		}
+		cnames, err := client.ListBucketCname(bucket.Name)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s 存储桶绑定的自定义域名失败: %s", bucket.Name, err)
+		}
+		for _, cname := range cnames.Cname {
+			if !strings.EqualFold(cname.Status, "Enabled") {
+				continue
+			}
+			domains = append(domains, cname.Domain)
+			cnameAttributes := map[string]string{schema.AttrEndpoint: endpoint, schema.AttrHTTPS: "off"}
+			if cname.Certificate.CertId != "" {
+				cnameAttributes[schema.AttrHTTPS] = "on"
+			}
+			resources = append(resources, d.newBucketResource(bucket, cname.Domain, cnameAttributes))
+		}
+	}
+	website := d.getBucketWebsite(client, bucket, domains)
+	for _, resource := range resources {
+		resource.Attributes[schema.AttrACL] = attributes[schema.AttrACL]
+		if website != "" {
+			resource.Attributes[schema.AttrWebsite] = website
+		}
+		ossList.Append(resource)
+	}
+
+	internal := d.newBucketResource(bucket, bucket.Name+".oss-"+bucket.Region+"-internal.aliyuncs.com",
+		map[string]string{schema.AttrEndpoint: endpoint})
+	internal.Public = false
+	internal.Intranet = true
+	ossList.Append(internal)
+}
+
+func (d *ossProvider) newBucketResource(bucket oss.BucketProperties, dnsName string, attributes map[string]string) *schema.Resource {
+	return &schema.Resource{
+		ID:         d.id,
+		Public:     true,
+		DNSName:    dnsName,
+		Provider:   d.provider,
+		Service:    "oss",
+		Region:     bucket.Region,
+		Attributes: attributes,
+	}
+}
+
+// bucketClient 返回存储桶所在地域的 OSS 客户端，存储桶需要使用其所在地域的 Endpoint 访问
+func (d *ossProvider) bucketClient(regionClients map[string]*oss.Client, bucket oss.BucketProperties) *oss.Client {
+	client, ok := regionClients[bucket.Region]
+	if !ok {
+		var err error
+		client, err = d.config.newOssClient(bucket.Region)
+		if err != nil {
+			gologger.Debug().Msgf("创建 %s 的 OSS 客户端失败: %s", bucket.Location, err)
+			return nil
+		}
+		regionClients[bucket.Region] = client
+	}
+	return client
+}
+
+// getBucketACL 获取存储桶的读写权限
+func (d *ossProvider) getBucketACL(client *oss.Client, bucket oss.BucketProperties) string {
+	if client == nil {
+		return ""
+	}
+	response, err := client.GetBucketACL(bucket.Name)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s 存储桶的 ACL 失败: %s", bucket.Name, err)
+		return ""
+	}
+	return response.ACL
+}
+
+// getBucketWebsite 返回存储桶静态网站托管的访问域名，存储桶绑定了自定义域名时使用第一个自定义域名，
+// 否则使用 <bucket>.oss-website-<region>.aliyuncs.com，未开启静态网站托管时返回空字符串
+func (d *ossProvider) getBucketWebsite(client *oss.Client, bucket oss.BucketProperties, domains []string) string {
+	if client == nil {
+		return ""
+	}
+	response, err := client.GetBucketWebsite(bucket.Name)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s 存储桶的静态网站托管配置失败: %s", bucket.Name, err)
+		return ""
+	}
+	if response.IndexDocument.Suffix == "" {
+		return ""
+	}
+	if len(domains) > 0 {
+		return domains[0]
+	}
+	return bucket.Name + ".oss-website-" + bucket.Region + ".aliyuncs.com"
+}
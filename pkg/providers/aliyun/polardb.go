@@ -0,0 +1,106 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/polardb"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+func (d *databaseProvider) GetPolardbResource() (*schema.Resources, error) {
+	polardbList := schema.NewResources()
+	forEachRegion(d.regionFilter.Filter(d.regions), func(region string) {
+		polardbClient, err := d.config.newPolardbClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 PolarDB 资源信息", region)
+		request := polardb.CreateDescribeDBClustersRequest()
+		request.PageSize = requests.NewInteger(databasePageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := polardbClient.DescribeDBClusters(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 PolarDB 集群失败: %s", region, err)
+				return
+			}
+			for _, cluster := range response.Items.DBCluster {
+				tags := make(map[string]string)
+				for _, tag := range cluster.Tags.Tag {
+					tags[tag.Key] = tag.Value
+				}
+				polardbList.Append(d.newPolardbResource(polardbClient, region, cluster.DBClusterId, tags))
+			}
+			if page*databasePageSize >= response.TotalRecordCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 PolarDB 数据", region)
+		}
+	})
+	return polardbList, nil
+}
+
+func (d *databaseProvider) newPolardbResource(polardbClient *polardb.Client, region, clusterId string, tags map[string]string) *schema.Resource {
+	resource := &schema.Resource{
+		ID:       d.id,
+		Provider: d.provider,
+		Service:  "polardb",
+		Region:   region,
+		Tags:     tags,
+	}
+	request := polardb.CreateDescribeDBClusterEndpointsRequest()
+	request.DBClusterId = clusterId
+	response, err := polardbClient.DescribeDBClusterEndpoints(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s PolarDB 集群的连接信息失败: %s", clusterId, err)
+		return resource
+	}
+	var publicPort, intranet string
+	for _, endpoint := range response.Items {
+		for _, address := range endpoint.AddressItems {
+			if address.NetType == "Public" {
+				resource.PublicIPv4 = address.ConnectionString
+				publicPort = address.Port
+			} else if intranet == "" {
+				intranet = address.ConnectionString
+				resource.PrivateIpv4 = address.IPAddress
+			}
+		}
+	}
+	if resource.PublicIPv4 == "" {
+		setIntranetEndpoint(resource, intranet)
+		return resource
+	}
+	resource.Public = true
+
+	var ips []string
+	whitelistRequest := polardb.CreateDescribeDBClusterAccessWhitelistRequest()
+	whitelistRequest.DBClusterId = clusterId
+	whitelistResponse, err := polardbClient.DescribeDBClusterAccessWhitelist(whitelistRequest)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s PolarDB 集群的白名单失败: %s", clusterId, err)
+	} else {
+		for _, ipArray := range whitelistResponse.Items.DBClusterIPArray {
+			ips = append(ips, ipArray.SecurityIps)
+		}
+	}
+	resource.Attributes = databaseAttributes(publicPort, ips)
+	setIntranetEndpoint(resource, intranet)
+	return resource
+}
+
+func describePolardbRegions(config providerConfig, region string) ([]string, error) {
+	polardbClient, err := config.newPolardbClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := polardbClient.DescribeRegions(polardb.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, polardbRegion := range response.Regions.Region {
+		regions = append(regions, polardbRegion.RegionId)
+	}
+	return regions, nil
+}
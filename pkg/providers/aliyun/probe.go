@@ -0,0 +1,268 @@
+package aliyun
+
+import (
+	"errors"
+	domain "github.com/alibabacloud-go/domain-20180129/v4/client"
+	fc "github.com/alibabacloud-go/fc-open-20210406/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
+	sdkerrors "github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cdn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cloudapi"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dcdn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/elasticsearch"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/nlb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/polardb"
+	r_kvstore "github.com/aliyun/alibaba-cloud-sdk-go/services/r-kvstore"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sae"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/slb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	swas "github.com/aliyun/alibaba-cloud-sdk-go/services/swas-open"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+var (
+	deniedCodes      = []string{"Forbidden", "NoPermission", "AccessDenied", "NotAuthorized", "Unauthorized"}
+	unactivatedCodes = []string{"NotActivated", "NotOpen", "NotApplied", "NotEnabled", "Unactivated", "UserDisable", "NotSubscribed"}
+)
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var (
+		region  = defaultRegion
+		results []*schema.ProbeResult
+	)
+	config, err := newProviderConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := options.GetMetadata(utils.Id)
+
+	for _, cloudService := range options.GetCloudServices(cs) {
+		var err error
+		gologger.Debug().Msgf("正在探测阿里云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "ecs":
+			var ecsClient *ecs.Client
+			ecsClient, err = config.newEcsClient(region)
+			if err == nil {
+				request := ecs.CreateDescribeInstancesRequest()
+				request.PageSize = "1"
+				_, err = ecsClient.DescribeInstances(request)
+			}
+		case "oss":
+			var ossClient *oss.Client
+			ossClient, err = config.newOssClient(region)
+			if err == nil {
+				_, err = ossClient.ListBuckets(oss.MaxKeys(1))
+			}
+		case "rds":
+			var rdsClient *rds.Client
+			rdsClient, err = config.newRdsClient(region)
+			if err == nil {
+				request := rds.CreateDescribeDBInstancesRequest()
+				request.PageSize = "30"
+				_, err = rdsClient.DescribeDBInstances(request)
+			}
+		case "fc":
+			err = probeFc(config, region)
+		case "domain":
+			var domainClient *domain.Client
+			domainClient, err = domain.NewClient(config.newOpenapiConfig("domain", region))
+			if err == nil {
+				_, err = domainClient.QueryDomainList(&domain.QueryDomainListRequest{
+					PageNum: tea.Int32(1), PageSize: tea.Int32(1),
+				})
+			}
+		case "slb":
+			var slbClient *slb.Client
+			slbClient, err = config.newSlbClient(region)
+			if err == nil {
+				request := slb.CreateDescribeLoadBalancersRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = slbClient.DescribeLoadBalancers(request)
+			}
+		case "alb":
+			var albClient *alb.Client
+			albClient, err = config.newAlbClient(region)
+			if err == nil {
+				request := alb.CreateListLoadBalancersRequest()
+				request.MaxResults = requests.NewInteger(1)
+				_, err = albClient.ListLoadBalancers(request)
+			}
+		case "nlb":
+			var nlbClient *nlb.Client
+			nlbClient, err = config.newNlbClient(region)
+			if err == nil {
+				request := nlb.CreateListLoadBalancersRequest()
+				request.MaxResults = requests.NewInteger(1)
+				_, err = nlbClient.ListLoadBalancers(request)
+			}
+		case "eip":
+			var vpcClient *vpc.Client
+			vpcClient, err = config.newVpcClient(region)
+			if err == nil {
+				request := vpc.CreateDescribeEipAddressesRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = vpcClient.DescribeEipAddresses(request)
+			}
+		case "nat":
+			var vpcClient *vpc.Client
+			vpcClient, err = config.newVpcClient(region)
+			if err == nil {
+				request := vpc.CreateDescribeNatGatewaysRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = vpcClient.DescribeNatGateways(request)
+			}
+		case "alidns":
+			var alidnsClient *alidns.Client
+			alidnsClient, err = config.newAlidnsClient(region)
+			if err == nil {
+				request := alidns.CreateDescribeDomainsRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = alidnsClient.DescribeDomains(request)
+			}
+		case "cdn":
+			var cdnClient *cdn.Client
+			cdnClient, err = config.newCdnClient(region)
+			if err == nil {
+				request := cdn.CreateDescribeUserDomainsRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = cdnClient.DescribeUserDomains(request)
+			}
+		case "dcdn":
+			var dcdnClient *dcdn.Client
+			dcdnClient, err = config.newDcdnClient(region)
+			if err == nil {
+				request := dcdn.CreateDescribeDcdnUserDomainsRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = dcdnClient.DescribeDcdnUserDomains(request)
+			}
+		case "ack":
+			err = probeAck(config, region)
+		case "redis":
+			var kvstoreClient *r_kvstore.Client
+			kvstoreClient, err = config.newKvstoreClient(region)
+			if err == nil {
+				request := r_kvstore.CreateDescribeInstancesRequest()
+				request.PageSize = requests.NewInteger(30)
+				_, err = kvstoreClient.DescribeInstances(request)
+			}
+		case "mongodb":
+			var ddsClient *dds.Client
+			ddsClient, err = config.newDdsClient(region)
+			if err == nil {
+				request := dds.CreateDescribeDBInstancesRequest()
+				request.PageSize = requests.NewInteger(30)
+				_, err = ddsClient.DescribeDBInstances(request)
+			}
+		case "polardb":
+			var polardbClient *polardb.Client
+			polardbClient, err = config.newPolardbClient(region)
+			if err == nil {
+				request := polardb.CreateDescribeDBClustersRequest()
+				request.PageSize = requests.NewInteger(30)
+				_, err = polardbClient.DescribeDBClusters(request)
+			}
+		case "elasticsearch":
+			var elasticsearchClient *elasticsearch.Client
+			elasticsearchClient, err = config.newElasticsearchClient(region)
+			if err == nil {
+				request := elasticsearch.CreateListInstanceRequest()
+				request.Size = requests.NewInteger(1)
+				_, err = elasticsearchClient.ListInstance(request)
+			}
+		case "apigateway":
+			var cloudapiClient *cloudapi.Client
+			cloudapiClient, err = config.newCloudapiClient(region)
+			if err == nil {
+				request := cloudapi.CreateDescribeApiGroupsRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = cloudapiClient.DescribeApiGroups(request)
+			}
+		case "sae":
+			var saeClient *sae.Client
+			saeClient, err = config.newSaeClient(region)
+			if err == nil {
+				request := sae.CreateListApplicationsRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = saeClient.ListApplications(request)
+			}
+		case "swas":
+			var swasClient *swas.Client
+			swasClient, err = config.newSwasClient(region)
+			if err == nil {
+				request := swas.CreateListInstancesRequest()
+				request.PageSize = requests.NewInteger(1)
+				_, err = swasClient.ListInstances(request)
+			}
+		default:
+			continue
+		}
+		results = append(results, schema.NewProbeResult(utils.Aliyun, id, cloudService, errorCode(err), err,
+			deniedCodes, unactivatedCodes))
+	}
+	return results, nil
+}
+
+func probeFc(config providerConfig, region string) error {
+	stsClient, err := config.newStsClient(region)
+	if err != nil {
+		return err
+	}
+	stsReq := sts.CreateGetCallerIdentityRequest()
+	identity, err := stsClient.GetCallerIdentity(stsReq)
+	if err != nil {
+		return err
+	}
+	f := &functionProvider{config: config, identity: identity}
+	fcClient, err := fc.NewClient(f.newFcConfig(region))
+	if err != nil {
+		return err
+	}
+	_, err = fcClient.ListServices(&fc.ListServicesRequest{Limit: tea.Int32(1)})
+	return err
+}
+
+// probeAck 探测容器服务的访问权限，Probe 的参数 cs 与容器服务的包名冲突，因此单独放在一个函数中
+func probeAck(config providerConfig, region string) error {
+	csClient, err := config.newCsClient(region)
+	if err != nil {
+		return err
+	}
+	request := cs.CreateDescribeClustersV1Request()
+	request.PageSize = requests.NewInteger(1)
+	_, err = csClient.DescribeClustersV1(request)
+	return err
+}
+
+// errorCode 从阿里云各个 SDK 返回的错误中取出错误码
+func errorCode(err error) string {
+	var (
+		serverError *sdkerrors.ServerError
+		teaError    *tea.SDKError
+		ossError    oss.ServiceError
+	)
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &serverError):
+		return serverError.ErrorCode()
+	case errors.As(err, &teaError):
+		return tea.StringValue(teaError.Code)
+	case errors.As(err, &ossError):
+		return ossError.Code
+	}
+	return ""
+}
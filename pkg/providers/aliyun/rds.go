@@ -2,12 +2,11 @@ package aliyun
 
 import (
 	"context"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
 	"github.com/wgpsec/lc/utils"
+	"strings"
 	"sync"
 )
 
@@ -16,6 +15,8 @@ type dbInstanceProvider struct {
 	provider   string
 	config     providerConfig
 	rdsRegions *rds.DescribeRegionsResponse
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
 }
 
 type rdsInstance struct {
@@ -23,8 +24,17 @@ type rdsInstance struct {
 	region string
 }
 
-var rdsInstances []rdsInstance
-var rdsList = schema.NewResources()
+// rdsInstances 是可以被多个协程同时追加的 RDS 实例列表
+type rdsInstances struct {
+	items []rdsInstance
+	sync.Mutex
+}
+
+func (r *rdsInstances) append(instance rdsInstance) {
+	r.Lock()
+	defer r.Unlock()
+	r.items = append(r.items, instance)
+}
 
 func (d *dbInstanceProvider) GetRdsResource(ctx context.Context) (*schema.Resources, error) {
 	var (
@@ -32,19 +42,21 @@ func (d *dbInstanceProvider) GetRdsResource(ctx context.Context) (*schema.Resour
 		err     error
 		wg      sync.WaitGroup
 		regions []string
+		// instances 保存各个协程获取到的 RDS 实例
+		instances = &rdsInstances{}
 	)
 	threads = schema.GetThreads()
 
 	for _, region := range d.rdsRegions.Regions.RDSRegion {
 		regions = append(regions, region.RegionId)
 	}
-	regions = utils.RemoveRepeatedElement(regions)
+	regions = d.regionFilter.Filter(utils.RemoveRepeatedElement(regions))
 
 	taskCh := make(chan string, threads)
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.describeRdsInstances(taskCh, &wg)
+			err = d.describeRdsInstances(taskCh, &wg, instances)
 			if err != nil {
 				return
 			}
@@ -55,14 +67,10 @@ func (d *dbInstanceProvider) GetRdsResource(ctx context.Context) (*schema.Resour
 	}
 	close(taskCh)
 	wg.Wait()
-	err = d.GetRdsConnectionString(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return rdsList, nil
+	return d.GetRdsConnectionString(ctx, instances.items)
 }
 
-func (d *dbInstanceProvider) describeRdsInstances(ch <-chan string, wg *sync.WaitGroup) error {
+func (d *dbInstanceProvider) describeRdsInstances(ch <-chan string, wg *sync.WaitGroup, instances *rdsInstances) error {
 	defer wg.Done()
 	var (
 		err       error
@@ -70,19 +78,9 @@ func (d *dbInstanceProvider) describeRdsInstances(ch <-chan string, wg *sync.Wai
 		response  *rds.DescribeDBInstancesResponse
 	)
 	for region := range ch {
-		rdsConfig := sdk.NewConfig()
-		if d.config.okST {
-			credential := credentials.NewStsTokenCredential(d.config.accessKeyID, d.config.accessKeySecret, d.config.sessionToken)
-			rdsClient, err = rds.NewClientWithOptions(region, rdsConfig, credential)
-			if err != nil {
-				continue
-			}
-		} else {
-			credential := credentials.NewAccessKeyCredential(d.config.accessKeyID, d.config.accessKeySecret)
-			rdsClient, err = rds.NewClientWithOptions(region, rdsConfig, credential)
-			if err != nil {
-				continue
-			}
+		rdsClient, err = d.config.newRdsClient(region)
+		if err != nil {
+			continue
 		}
 		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 RDS 资源信息", region)
 		request := rds.CreateDescribeDBInstancesRequest()
@@ -95,7 +93,7 @@ func (d *dbInstanceProvider) describeRdsInstances(ch <-chan string, wg *sync.Wai
 				gologger.Warning().Msgf("在 %s 区域下获取到 %d 条 RDS 资源", region, len(response.Items.DBInstance))
 			}
 			for _, DBInstance := range response.Items.DBInstance {
-				rdsInstances = append(rdsInstances, rdsInstance{
+				instances.append(rdsInstance{
 					dbId:   DBInstance.DBInstanceId,
 					region: region,
 				})
@@ -111,36 +109,26 @@ func (d *dbInstanceProvider) describeRdsInstances(ch <-chan string, wg *sync.Wai
 	return err
 }
 
-func (d *dbInstanceProvider) GetRdsConnectionString(ctx context.Context) error {
+func (d *dbInstanceProvider) GetRdsConnectionString(ctx context.Context, instances []rdsInstance) (*schema.Resources, error) {
 	var (
-		private   string
-		public    string
 		err       error
 		rdsClient *rds.Client
 		response  *rds.DescribeDBInstanceNetInfoResponse
+		rdsList   = schema.NewResources()
 	)
-	for _, dbInstance := range rdsInstances {
+	for _, dbInstance := range instances {
+		var private, public string
 		gologger.Debug().Msgf("正在获取 %s RDS 实例的连接信息", dbInstance.dbId)
-		rdsConfig := sdk.NewConfig()
-		if d.config.okST {
-			credential := credentials.NewStsTokenCredential(d.config.accessKeyID, d.config.accessKeySecret, d.config.sessionToken)
-			rdsClient, err = rds.NewClientWithOptions(dbInstance.region, rdsConfig, credential)
-			if err != nil {
-				continue
-			}
-		} else {
-			credential := credentials.NewAccessKeyCredential(d.config.accessKeyID, d.config.accessKeySecret)
-			rdsClient, err = rds.NewClientWithOptions(dbInstance.region, rdsConfig, credential)
-			if err != nil {
-				continue
-			}
+		rdsClient, err = d.config.newRdsClient(dbInstance.region)
+		if err != nil {
+			continue
 		}
 		request := rds.CreateDescribeDBInstanceNetInfoRequest()
 		request.DBInstanceId = dbInstance.dbId
 
 		response, err = rdsClient.DescribeDBInstanceNetInfo(request)
 		if err != nil {
-			return nil
+			return rdsList, nil
 		}
 		for _, DBInstanceNetInfo := range response.DBInstanceNetInfos.DBInstanceNetInfo {
 			if DBInstanceNetInfo.IPType == "Private" {
@@ -149,13 +137,38 @@ func (d *dbInstanceProvider) GetRdsConnectionString(ctx context.Context) error {
 				public = DBInstanceNetInfo.ConnectionString
 			}
 		}
+		var attributes map[string]string
+		if public != "" {
+			attributes = map[string]string{schema.AttrWhitelist: d.describeRdsWhitelist(rdsClient, dbInstance.dbId)}
+		}
 		rdsList.Append(&schema.Resource{
 			ID:          d.id,
 			Provider:    d.provider,
+			Service:     "rds",
+			Region:      dbInstance.region,
 			PublicIPv4:  public,
 			PrivateIpv4: private,
 			Public:      public != "",
+			Attributes:  attributes,
 		})
 	}
-	return err
+	return rdsList, err
+}
+
+// describeRdsWhitelist 返回 RDS 实例所有白名单分组中的 IP 地址
+func (d *dbInstanceProvider) describeRdsWhitelist(rdsClient *rds.Client, dbId string) string {
+	var ips []string
+	request := rds.CreateDescribeDBInstanceIPArrayListRequest()
+	request.DBInstanceId = dbId
+	response, err := rdsClient.DescribeDBInstanceIPArrayList(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s RDS 实例的白名单失败: %s", dbId, err)
+		return ""
+	}
+	for _, ipArray := range response.Items.DBInstanceIPArray {
+		if ipArray.SecurityIPList != "" {
+			ips = append(ips, ipArray.SecurityIPList)
+		}
+	}
+	return strings.Join(utils.RemoveRepeatedElement(strings.Split(strings.Join(ips, ","), ",")), ",")
 }
@@ -0,0 +1,146 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	r_kvstore "github.com/aliyun/alibaba-cloud-sdk-go/services/r-kvstore"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strings"
+)
+
+// databaseProvider 列出 Redis、MongoDB、PolarDB 和 Elasticsearch 实例的连接地址
+type databaseProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+const databasePageSize = 100
+
+func (d *databaseProvider) GetRedisResource() (*schema.Resources, error) {
+	redisList := schema.NewResources()
+	forEachRegion(d.regionFilter.Filter(d.regions), func(region string) {
+		kvstoreClient, err := d.config.newKvstoreClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 Redis 资源信息", region)
+		request := r_kvstore.CreateDescribeInstancesRequest()
+		request.PageSize = requests.NewInteger(databasePageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := kvstoreClient.DescribeInstances(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 Redis 实例失败: %s", region, err)
+				return
+			}
+			for _, instance := range response.Instances.KVStoreInstance {
+				tags := make(map[string]string)
+				for _, tag := range instance.Tags.Tag {
+					tags[tag.Key] = tag.Value
+				}
+				redisList.Append(d.newRedisResource(kvstoreClient, region, instance.InstanceId, tags))
+			}
+			if page*databasePageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 Redis 数据", region)
+		}
+	})
+	return redisList, nil
+}
+
+func (d *databaseProvider) newRedisResource(kvstoreClient *r_kvstore.Client, region, instanceId string, tags map[string]string) *schema.Resource {
+	resource := &schema.Resource{
+		ID:       d.id,
+		Provider: d.provider,
+		Service:  "redis",
+		Region:   region,
+		Tags:     tags,
+	}
+	request := r_kvstore.CreateDescribeDBInstanceNetInfoRequest()
+	request.InstanceId = instanceId
+	response, err := kvstoreClient.DescribeDBInstanceNetInfo(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s Redis 实例的连接信息失败: %s", instanceId, err)
+		return resource
+	}
+	var publicPort, intranet string
+	for _, netInfo := range response.NetInfoItems.InstanceNetInfo {
+		if netInfo.IPType == "Public" {
+			resource.PublicIPv4 = netInfo.ConnectionString
+			publicPort = netInfo.Port
+		} else if intranet == "" {
+			intranet = netInfo.ConnectionString
+			resource.PrivateIpv4 = netInfo.IPAddress
+		}
+	}
+	if resource.PublicIPv4 == "" {
+		setIntranetEndpoint(resource, intranet)
+		return resource
+	}
+	resource.Public = true
+
+	var ips []string
+	ipsRequest := r_kvstore.CreateDescribeSecurityIpsRequest()
+	ipsRequest.InstanceId = instanceId
+	ipsResponse, err := kvstoreClient.DescribeSecurityIps(ipsRequest)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s Redis 实例的白名单失败: %s", instanceId, err)
+	} else {
+		for _, group := range ipsResponse.SecurityIpGroups.SecurityIpGroup {
+			ips = append(ips, group.SecurityIpList)
+		}
+	}
+	resource.Attributes = databaseAttributes(publicPort, ips)
+	setIntranetEndpoint(resource, intranet)
+	return resource
+}
+
+// databaseAttributes 返回数据库公网地址的端口和白名单属性，多个白名单分组会合并去重
+func databaseAttributes(port string, whitelists []string) map[string]string {
+	var ips []string
+	for _, whitelist := range whitelists {
+		for _, ip := range strings.Split(whitelist, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	attributes := map[string]string{schema.AttrWhitelist: strings.Join(utils.RemoveRepeatedElement(ips), ",")}
+	if port != "" && port != "0" {
+		attributes[schema.AttrOpenPorts] = port
+	}
+	return attributes
+}
+
+// setIntranetEndpoint 将内网连接地址记录在属性中，内网连接地址是只能在内网解析的域名，不作为单独的资产输出
+func setIntranetEndpoint(resource *schema.Resource, endpoint string) {
+	if endpoint == "" {
+		return
+	}
+	if resource.Attributes == nil {
+		resource.Attributes = make(map[string]string)
+	}
+	resource.Attributes[schema.AttrIntranet] = endpoint
+}
+
+func describeRedisRegions(config providerConfig, region string) ([]string, error) {
+	kvstoreClient, err := config.newKvstoreClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := kvstoreClient.DescribeRegions(r_kvstore.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, kvstoreRegion := range response.RegionIds.KVStoreRegion {
+		regions = append(regions, kvstoreRegion.RegionId)
+	}
+	return regions, nil
+}
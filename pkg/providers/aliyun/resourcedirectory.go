@@ -0,0 +1,88 @@
+package aliyun
+
+import (
+	"fmt"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/resourcemanager"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strings"
+)
+
+const defaultResourceDirectoryRole = "ResourceDirectoryAccountAccessRole"
+
+// ResourceDirectoryEnabled 判断配置是否开启了通过资源目录列出所有成员账号
+func ResourceDirectoryEnabled(options schema.OptionBlock) bool {
+	enabled, _ := options.GetMetadata(utils.ResourceDirectory)
+	return strings.EqualFold(enabled, "true")
+}
+
+// ResourceDirectoryAccounts 通过资源目录列出所有成员账号，并为每个成员账号生成一个扮演其访问角色的配置，
+// 管理账号自身直接使用原配置
+func ResourceDirectoryAccounts(options schema.OptionBlock) ([]schema.OptionBlock, error) {
+	var (
+		region = "cn-hangzhou"
+		blocks []schema.OptionBlock
+	)
+	config, err := newProviderConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	roleName, ok := options.GetMetadata(utils.ResourceDirectoryRole)
+	if !ok {
+		roleName = defaultResourceDirectoryRole
+	}
+
+	stsClient, err := config.newStsClient(region)
+	if err != nil {
+		return nil, err
+	}
+	stsReq := sts.CreateGetCallerIdentityRequest()
+	identity, err := stsClient.GetCallerIdentity(stsReq)
+	if err != nil {
+		return nil, err
+	}
+
+	rmClient, err := config.newResourceManagerClient(region)
+	if err != nil {
+		return nil, err
+	}
+	request := resourcemanager.CreateListAccountsRequest()
+	request.PageSize = requests.NewInteger(100)
+	for pageNumber := 1; ; pageNumber++ {
+		request.PageNumber = requests.NewInteger(pageNumber)
+		response, err := rmClient.ListAccounts(request)
+		if err != nil {
+			return nil, fmt.Errorf("无法列出资源目录中的成员账号: %s", err)
+		}
+		for _, account := range response.Accounts.Account {
+			if !strings.HasSuffix(account.Status, "Success") {
+				gologger.Debug().Msgf("跳过状态为 %s 的成员账号 %s (%s)", account.Status, account.DisplayName, account.AccountId)
+				continue
+			}
+			block := make(schema.OptionBlock, len(options)+2)
+			for key, value := range options {
+				block[key] = value
+			}
+			delete(block, utils.ResourceDirectory)
+			block[utils.Id] = id + "/" + account.AccountId
+			block[utils.AccountId] = account.AccountId
+			if account.AccountId != identity.AccountId {
+				roleArn := fmt.Sprintf("acs:ram::%s:role/%s", account.AccountId, roleName)
+				if sourceRoleArn, ok := options.GetMetadata(utils.RoleArn); ok {
+					roleArn = sourceRoleArn + "," + roleArn
+				}
+				block[utils.RoleArn] = roleArn
+			}
+			blocks = append(blocks, block)
+		}
+		if pageNumber*response.PageSize >= response.TotalCount || len(response.Accounts.Account) == 0 {
+			break
+		}
+	}
+	gologger.Info().Msgf("通过资源目录发现 %d 个阿里云成员账号", len(blocks))
+	return blocks, nil
+}
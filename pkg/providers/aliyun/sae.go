@@ -0,0 +1,158 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sae"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strconv"
+	"strings"
+)
+
+const saePageSize = 100
+
+// saeProvider 列出 SAE 应用的公网负载均衡地址和网关路由中绑定的域名
+type saeProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+func (s *saeProvider) GetResource() (*schema.Resources, error) {
+	saeList := schema.NewResources()
+	forEachRegion(s.regionFilter.Filter(s.regions), func(region string) {
+		saeClient, err := s.config.newSaeClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 SAE 资源信息", region)
+		var namespaces []string
+		request := sae.CreateListApplicationsRequest()
+		request.PageSize = requests.NewInteger(saePageSize)
+		for page := 1; ; page++ {
+			request.CurrentPage = requests.NewInteger(page)
+			response, err := saeClient.ListApplications(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 SAE 应用失败: %s", region, err)
+				return
+			}
+			for _, application := range response.Data.Applications {
+				tags := make(map[string]string)
+				for _, tag := range application.Tags {
+					tags[tag.Key] = tag.Value
+				}
+				saeList.Append(s.newApplicationResource(saeClient, region, application.AppId, tags))
+				namespaces = append(namespaces, application.NamespaceId)
+			}
+			if page*saePageSize >= response.Data.TotalSize {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 SAE 应用数据", region)
+		}
+		for _, namespaceId := range utils.RemoveRepeatedElement(namespaces) {
+			for _, resource := range s.ingressDomains(saeClient, region, namespaceId) {
+				saeList.Append(resource)
+			}
+		}
+	})
+	return saeList, nil
+}
+
+// newApplicationResource 返回 SAE 应用绑定的公网和私网负载均衡地址
+func (s *saeProvider) newApplicationResource(saeClient *sae.Client, region, appId string, tags map[string]string) *schema.Resource {
+	resource := &schema.Resource{
+		ID:       s.id,
+		Provider: s.provider,
+		Service:  "sae",
+		Region:   region,
+		Tags:     tags,
+	}
+	request := sae.CreateDescribeApplicationSlbsRequest()
+	request.AppId = appId
+	response, err := saeClient.DescribeApplicationSlbs(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s SAE 应用的负载均衡信息失败: %s", appId, err)
+		return resource
+	}
+	resource.PrivateIpv4 = response.Data.IntranetIp
+	if response.Data.InternetIp == "" {
+		return resource
+	}
+	var listeners []lbListener
+	for _, internet := range response.Data.Internet {
+		listeners = append(listeners, lbListener{protocol: internet.Protocol, port: strconv.Itoa(internet.Port)})
+	}
+	resource.PublicIPv4 = response.Data.InternetIp
+	resource.Public = true
+	resource.Attributes = listenerAttributes(listeners)
+	if response.Data.InternetSlbId != "" {
+		if resource.Attributes == nil {
+			resource.Attributes = make(map[string]string)
+		}
+		resource.Attributes[schema.AttrLoadBalancerID] = response.Data.InternetSlbId
+	}
+	return resource
+}
+
+// ingressDomains 返回命名空间下公网网关路由规则中配置的域名，使用私网 SLB 的网关路由只能在内网访问，会被跳过
+func (s *saeProvider) ingressDomains(saeClient *sae.Client, region, namespaceId string) []*schema.Resource {
+	var resources []*schema.Resource
+	request := sae.CreateListIngressesRequest()
+	request.NamespaceId = namespaceId
+	response, err := saeClient.ListIngresses(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s 命名空间的网关路由失败: %s", namespaceId, err)
+		return nil
+	}
+	for _, ingress := range response.Data.IngressList {
+		if strings.EqualFold(ingress.LoadBalanceType, "intranet") {
+			continue
+		}
+		ingressRequest := sae.CreateDescribeIngressRequest()
+		ingressRequest.IngressId = requests.NewInteger(int(ingress.Id))
+		ingressResponse, err := saeClient.DescribeIngress(ingressRequest)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %d 网关路由的规则失败: %s", ingress.Id, err)
+			continue
+		}
+		attributes := listenerAttributes([]lbListener{{protocol: ingress.ListenerProtocol, port: ingress.ListenerPort}})
+		if ingress.SlbId != "" {
+			attributes[schema.AttrLoadBalancerID] = ingress.SlbId
+		}
+		for _, rule := range ingressResponse.Data.Rules {
+			if rule.Domain == "" {
+				continue
+			}
+			resources = append(resources, &schema.Resource{
+				ID:         s.id,
+				Provider:   s.provider,
+				Service:    "sae",
+				Region:     region,
+				DNSName:    rule.Domain,
+				Public:     true,
+				Attributes: attributes,
+			})
+		}
+	}
+	return resources
+}
+
+func describeSaeRegions(config providerConfig, region string) ([]string, error) {
+	saeClient, err := config.newSaeClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := saeClient.DescribeRegions(sae.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, saeRegion := range response.Regions.Region {
+		regions = append(regions, saeRegion.RegionId)
+	}
+	return regions, nil
+}
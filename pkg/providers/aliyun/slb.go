@@ -0,0 +1,114 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/slb"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strconv"
+	"strings"
+)
+
+// loadBalancerProvider 列出 SLB、ALB 和 NLB 中公网类型的负载均衡
+type loadBalancerProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+// lbListener 是负载均衡的一个监听，port 可以是 1-65535 形式的端口范围
+type lbListener struct {
+	protocol string
+	port     string
+}
+
+const slbPageSize = 100
+
+func (l *loadBalancerProvider) GetSlbResource() (*schema.Resources, error) {
+	slbList := schema.NewResources()
+	forEachRegion(l.regionFilter.Filter(l.regions), func(region string) {
+		slbClient, err := l.config.newSlbClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云 SLB 资源信息", region)
+		request := slb.CreateDescribeLoadBalancersRequest()
+		request.AddressType = "internet"
+		request.PageSize = requests.NewInteger(slbPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := slbClient.DescribeLoadBalancers(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的 SLB 失败: %s", region, err)
+				return
+			}
+			for _, loadBalancer := range response.LoadBalancers.LoadBalancer {
+				slbList.Append(&schema.Resource{
+					ID:         l.id,
+					Provider:   l.provider,
+					Service:    "slb",
+					Region:     region,
+					PublicIPv4: loadBalancer.Address,
+					Public:     true,
+					Attributes: listenerAttributes(l.slbListeners(slbClient, loadBalancer.LoadBalancerId)),
+				})
+			}
+			if page*slbPageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页 SLB 数据", region)
+		}
+	})
+	return slbList, nil
+}
+
+func (l *loadBalancerProvider) slbListeners(slbClient *slb.Client, loadBalancerId string) []lbListener {
+	var listeners []lbListener
+	request := slb.CreateDescribeLoadBalancerAttributeRequest()
+	request.LoadBalancerId = loadBalancerId
+	response, err := slbClient.DescribeLoadBalancerAttribute(request)
+	if err != nil {
+		gologger.Debug().Msgf("获取 %s SLB 的监听失败: %s", loadBalancerId, err)
+		return nil
+	}
+	for _, listener := range response.ListenerPortsAndProtocol.ListenerPortAndProtocol {
+		listeners = append(listeners, lbListener{protocol: listener.ListenerProtocol, port: strconv.Itoa(listener.ListenerPort)})
+	}
+	return listeners
+}
+
+// listenerAttributes 返回负载均衡监听的端口和协议属性，没有监听时返回 nil
+func listenerAttributes(listeners []lbListener) map[string]string {
+	if len(listeners) == 0 {
+		return nil
+	}
+	var ports, protocols []string
+	for _, listener := range listeners {
+		ports = append(ports, listener.port)
+		protocols = append(protocols, strings.ToUpper(listener.protocol)+":"+listener.port)
+	}
+	return map[string]string{
+		schema.AttrOpenPorts: strings.Join(utils.RemoveRepeatedElement(ports), ","),
+		schema.AttrListeners: strings.Join(protocols, ","),
+	}
+}
+
+func describeSlbRegions(config providerConfig, region string) ([]string, error) {
+	slbClient, err := config.newSlbClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := slbClient.DescribeRegions(slb.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, slbRegion := range response.Regions.Region {
+		regions = append(regions, slbRegion.RegionId)
+	}
+	return regions, nil
+}
@@ -0,0 +1,47 @@
+package aliyun
+
+import (
+	"github.com/wgpsec/lc/pkg/schema"
+	"reflect"
+	"testing"
+)
+
+func TestListenerAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		listeners []lbListener
+		want      map[string]string
+	}{
+		{
+			name: "no listeners",
+			want: nil,
+		},
+		{
+			name:      "single listener",
+			listeners: []lbListener{{protocol: "https", port: "443"}},
+			want: map[string]string{
+				schema.AttrOpenPorts: "443",
+				schema.AttrListeners: "HTTPS:443",
+			},
+		},
+		{
+			name: "same port on two protocols",
+			listeners: []lbListener{
+				{protocol: "tcp", port: "80"},
+				{protocol: "udp", port: "80"},
+				{protocol: "HTTP", port: "8080"},
+			},
+			want: map[string]string{
+				schema.AttrOpenPorts: "80,8080",
+				schema.AttrListeners: "TCP:80,UDP:80,HTTP:8080",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listenerAttributes(tt.listeners); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listenerAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	swas "github.com/aliyun/alibaba-cloud-sdk-go/services/swas-open"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strings"
+)
+
+const swasPageSize = 100
+
+// swasProvider 列出轻量应用服务器实例和实例的防火墙规则
+type swasProvider struct {
+	id       string
+	provider string
+	config   providerConfig
+	regions  []string
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+}
+
+func (s *swasProvider) GetResource() (*schema.Resources, error) {
+	swasList := schema.NewResources()
+	forEachRegion(s.regionFilter.Filter(s.regions), func(region string) {
+		swasClient, err := s.config.newSwasClient(region)
+		if err != nil {
+			return
+		}
+		gologger.Debug().Msgf("正在获取 %s 区域下的阿里云轻量应用服务器资源信息", region)
+		request := swas.CreateListInstancesRequest()
+		request.PageSize = requests.NewInteger(swasPageSize)
+		for page := 1; ; page++ {
+			request.PageNumber = requests.NewInteger(page)
+			response, err := swasClient.ListInstances(request)
+			if err != nil {
+				gologger.Debug().Msgf("获取 %s 区域下的轻量应用服务器失败: %s", region, err)
+				return
+			}
+			for _, instance := range response.Instances {
+				tags := make(map[string]string)
+				for _, tag := range instance.Tags {
+					tags[tag.Key] = tag.Value
+				}
+				resource := &schema.Resource{
+					ID:          s.id,
+					Provider:    s.provider,
+					Service:     "swas",
+					Region:      region,
+					PublicIPv4:  instance.PublicIpAddress,
+					PrivateIpv4: instance.InnerIpAddress,
+					Public:      instance.PublicIpAddress != "",
+					Tags:        tags,
+				}
+				if resource.Public {
+					resource.Attributes = s.firewallAttributes(swasClient, instance.InstanceId)
+				}
+				swasList.Append(resource)
+			}
+			if page*swasPageSize >= response.TotalCount {
+				break
+			}
+			gologger.Debug().Msgf("正在获取 %s 区域下的下一页轻量应用服务器数据", region)
+		}
+	})
+	return swasList, nil
+}
+
+// firewallAttributes 返回实例的防火墙规则，以及允许所有来源访问的 TCP 端口
+func (s *swasProvider) firewallAttributes(swasClient *swas.Client, instanceId string) map[string]string {
+	var openPorts, rules []string
+	request := swas.CreateListFirewallRulesRequest()
+	request.InstanceId = instanceId
+	request.PageSize = requests.NewInteger(swasPageSize)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		response, err := swasClient.ListFirewallRules(request)
+		if err != nil {
+			gologger.Debug().Msgf("获取 %s 轻量应用服务器的防火墙规则失败: %s", instanceId, err)
+			break
+		}
+		for _, rule := range response.FirewallRules {
+			if rule.Policy != "" && !strings.EqualFold(rule.Policy, "accept") {
+				continue
+			}
+			// 未填写来源地址的规则允许所有来源访问
+			source := rule.SourceCidrIp
+			if source == "" {
+				source = "0.0.0.0/0"
+			}
+			port := rule.Port
+			if strings.Contains(port, "/") {
+				port = normalizePortRange(port)
+			}
+			rules = append(rules, strings.ToUpper(rule.RuleProtocol)+":"+port+":"+source)
+			if source == "0.0.0.0/0" && strings.Contains(strings.ToUpper(rule.RuleProtocol), "TCP") {
+				openPorts = append(openPorts, port)
+			}
+		}
+		if page*swasPageSize >= response.TotalCount {
+			break
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return map[string]string{
+		schema.AttrOpenPorts:     strings.Join(openPorts, ","),
+		schema.AttrFirewallRules: strings.Join(rules, ","),
+	}
+}
+
+func describeSwasRegions(config providerConfig, region string) ([]string, error) {
+	swasClient, err := config.newSwasClient(region)
+	if err != nil {
+		return nil, err
+	}
+	response, err := swasClient.ListRegions(swas.CreateListRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, swasRegion := range response.Regions {
+		regions = append(regions, swasRegion.RegionId)
+	}
+	return regions, nil
+}
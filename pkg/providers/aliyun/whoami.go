@@ -0,0 +1,33 @@
+package aliyun
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+// WhoAmI 通过 STS GetCallerIdentity 获取访问凭证所属的身份信息
+func WhoAmI(options schema.OptionBlock) (*schema.Identity, error) {
+	config, err := newProviderConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	stsClient, err := config.newStsClient(defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	stsReq := sts.CreateGetCallerIdentityRequest()
+	identity, err := stsClient.GetCallerIdentity(stsReq)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Identity{
+		Provider:  utils.Aliyun,
+		ID:        id,
+		AccountID: identity.AccountId,
+		UserID:    identity.UserId,
+		Arn:       identity.Arn,
+		Temporary: config.okST || identity.IdentityType == "AssumedRoleUser",
+	}, nil
+}
@@ -17,6 +17,7 @@ type Provider struct {
 	bosClient     *bos.Client
 	config        providerConfig
 	cloudServices []string
+	regionFilter  schema.RegionFilter
 }
 
 type providerConfig struct {
@@ -24,11 +25,15 @@ type providerConfig struct {
 	accessKeySecret string
 	sessionToken    string
 	okST            bool
+	// endpoints 是配置中指定的服务地址
+	endpoints schema.Endpoints
+	// proxy 是访问云服务时使用的代理地址
+	proxy string
 }
 
 func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error) {
 	var (
-		endpoint      = "https://bj.bcebos.com"
+		region        = "bj"
 		err           error
 		bosClient     *bos.Client
 		cloudServices []string
@@ -43,6 +48,11 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 	}
 	id, _ := options.GetMetadata(utils.Id)
 	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+	endpoints := options.GetEndpoints()
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return nil, err
+	}
 
 	if okST {
 		gologger.Debug().Msg("找到百度云访问临时访问凭证")
@@ -60,8 +70,9 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		switch cloudService {
 		case "bos":
 			// bos client
+			endpoint := schema.EndpointURL(endpoints.Resolve("bos", region, region+".bcebos.com"))
 			if okST {
-				bosClient, err = bos.NewClient(accessKeyID, accessKeySecret, "")
+				bosClient, err = bos.NewClient(accessKeyID, accessKeySecret, endpoint)
 				if err != nil {
 					return nil, err
 				}
@@ -85,6 +96,7 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			if err != nil {
 				return nil, err
 			}
+			bosClient.Config.ProxyUrl = proxy
 		}
 	}
 	config := providerConfig{
@@ -92,9 +104,12 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		accessKeySecret: accessKeySecret,
 		sessionToken:    sessionToken,
 		okST:            okST,
+		endpoints:       endpoints,
+		proxy:           proxy,
 	}
 
-	return &Provider{provider: utils.Baidu, id: id, bosClient: bosClient, config: config, cloudServices: cloudServices}, nil
+	return &Provider{provider: utils.Baidu, id: id, bosClient: bosClient, config: config, cloudServices: cloudServices,
+		regionFilter: options.GetRegionFilter()}, nil
 }
 
 func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*schema.Resources, error) {
@@ -102,7 +117,7 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 	for _, cloudService := range p.cloudServices {
 		switch cloudService {
 		case "bcc":
-			bccProvider := &instanceProvider{provider: p.provider, id: p.id, config: p.config}
+			bccProvider := &instanceProvider{provider: p.provider, id: p.id, config: p.config, regionFilter: p.regionFilter}
 			lists, err := bccProvider.GetResource(ctx)
 			if err != nil {
 				return nil, err
@@ -13,40 +13,32 @@ type instanceProvider struct {
 	id       string
 	provider string
 	config   providerConfig
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
 }
 
-var list = schema.NewResources()
-
 func (d *instanceProvider) GetResource(ctx context.Context) (*schema.Resources, error) {
 	var (
 		threads int
 		err     error
 		wg      sync.WaitGroup
+		list    = schema.NewResources()
 	)
-	var endpoints = []string{
-		"https://bcc.bj.baidubce.com",
-		"https://bcc.gz.baidubce.com",
-		"https://bcc.su.baidubce.com",
-		"https://bcc.hkg.baidubce.com",
-		"https://bcc.fwh.baidubce.com",
-		"https://bcc.bd.baidubce.com",
-		"https://bcc.cd.baidubce.com",
-		"https://bcc.nj.baidubce.com",
-		"https://bcc.fsh.baidubce.com",
-	}
+	var regions = []string{"bj", "gz", "su", "hkg", "fwh", "bd", "cd", "nj", "fsh"}
+	regions = d.regionFilter.Filter(regions)
 	threads = schema.GetThreads()
 
 	taskCh := make(chan string, threads)
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.describeInstances(taskCh, &wg)
+			err = d.describeInstances(taskCh, &wg, list)
 			if err != nil {
 				return
 			}
 		}()
 	}
-	for _, item := range endpoints {
+	for _, item := range regions {
 		taskCh <- item
 	}
 	close(taskCh)
@@ -54,15 +46,16 @@ func (d *instanceProvider) GetResource(ctx context.Context) (*schema.Resources,
 	return list, nil
 }
 
-func (d *instanceProvider) describeInstances(ch <-chan string, wg *sync.WaitGroup) error {
+func (d *instanceProvider) describeInstances(ch <-chan string, wg *sync.WaitGroup, list *schema.Resources) error {
 	defer wg.Done()
 	var (
 		err       error
 		bccClient *bcc.Client
 	)
-	for endpoint := range ch {
+	for region := range ch {
+		endpoint := schema.EndpointURL(d.config.endpoints.Resolve("bcc", region, "bcc."+region+".baidubce.com"))
 		if d.config.okST {
-			bccClient, err = bcc.NewClient(d.config.accessKeyID, d.config.accessKeySecret, "")
+			bccClient, err = bcc.NewClient(d.config.accessKeyID, d.config.accessKeySecret, endpoint)
 			if err != nil {
 				continue
 			}
@@ -80,6 +73,7 @@ func (d *instanceProvider) describeInstances(ch <-chan string, wg *sync.WaitGrou
 				continue
 			}
 		}
+		bccClient.Config.ProxyUrl = d.config.proxy
 		listArgs := &api.ListInstanceArgs{}
 		for {
 			response, err := bccClient.ListInstances(listArgs)
@@ -96,6 +90,8 @@ func (d *instanceProvider) describeInstances(ch <-chan string, wg *sync.WaitGrou
 				list.Append(&schema.Resource{
 					ID:          d.id,
 					Provider:    d.provider,
+					Service:     "bcc",
+					Region:      region,
 					PublicIPv4:  ipv4,
 					PrivateIpv4: privateIPv4,
 					Public:      ipv4 != "",
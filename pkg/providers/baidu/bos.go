@@ -31,6 +31,8 @@ func (d *bosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 			Public:   true,
 			DNSName:  endpointBuilder.String(),
 			Provider: d.provider,
+			Service:  "bos",
+			Region:   bucket.Location,
 		})
 	}
 	return list, nil
@@ -0,0 +1,59 @@
+package baidu
+
+import (
+	"errors"
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bcc"
+	"github.com/baidubce/bce-sdk-go/services/bcc/api"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+var (
+	deniedCodes      = []string{"AccessDenied", "Forbidden", "NoPermission"}
+	unactivatedCodes = []string{"NotActivated", "ServiceNotOpen", "Unactivated"}
+)
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var results []*schema.ProbeResult
+	p, err := New(options, cs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cloudService := range p.cloudServices {
+		var code string
+		gologger.Debug().Msgf("正在探测百度云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "bcc":
+			var bccClient *bcc.Client
+			bccClient, err = bcc.NewClient(p.config.accessKeyID, p.config.accessKeySecret,
+				schema.EndpointURL(p.config.endpoints.Resolve("bcc", "bj", "bcc.bj.baidubce.com")))
+			if err == nil && p.config.okST {
+				var stsCredential *auth.BceCredentials
+				stsCredential, err = auth.NewSessionBceCredentials(
+					p.config.accessKeyID,
+					p.config.accessKeySecret,
+					p.config.sessionToken)
+				bccClient.Config.Credentials = stsCredential
+			}
+			if err == nil {
+				bccClient.Config.ProxyUrl = p.config.proxy
+				_, err = bccClient.ListInstances(&api.ListInstanceArgs{MaxKeys: 1})
+			}
+		case "bos":
+			_, err = p.bosClient.ListBuckets()
+		default:
+			continue
+		}
+		var bceError *bce.BceServiceError
+		if errors.As(err, &bceError) {
+			code = bceError.Code
+		}
+		results = append(results, schema.NewProbeResult(p.provider, p.id, cloudService, code, err,
+			deniedCodes, unactivatedCodes))
+	}
+	return results, nil
+}
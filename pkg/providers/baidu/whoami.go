@@ -0,0 +1,46 @@
+package baidu
+
+import (
+	"github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/services/sts"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+// WhoAmI 百度云没有单独的身份查询接口，这里通过 STS GetSessionToken 返回的 UserId 获取访问凭证所属的身份信息
+func WhoAmI(options schema.OptionBlock) (*schema.Identity, error) {
+	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.AccessKey}
+	}
+	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+
+	stsClient, err := sts.NewClient(accessKeyID, accessKeySecret)
+	if err != nil {
+		return nil, err
+	}
+	if stsClient.Config.ProxyUrl, err = utils.GetProxy(options); err != nil {
+		return nil, err
+	}
+	if endpoint, ok := options.GetEndpoints().Get("sts", ""); ok {
+		stsClient.Config.Endpoint = schema.EndpointURL(endpoint)
+	}
+	if okST {
+		stsCredential, err := auth.NewSessionBceCredentials(accessKeyID, accessKeySecret, sessionToken)
+		if err != nil {
+			return nil, err
+		}
+		stsClient.Config.Credentials = stsCredential
+	}
+	// 申请一个有效期最短的临时凭证，只使用其中的 UserId
+	token, err := stsClient.GetSessionToken(60, "")
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Identity{Provider: utils.Baidu, ID: id, AccountID: token.UserId, Temporary: okST}, nil
+}
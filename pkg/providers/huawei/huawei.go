@@ -46,14 +46,20 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 	} else {
 		cloudServices = cs
 	}
+	endpoints := options.GetEndpoints()
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return nil, err
+	}
 	for _, cloudService := range cloudServices {
 		switch cloudService {
 		case "obs":
 			// obs client
+			endpoint := schema.EndpointURL(endpoints.Resolve("obs", region, "obs."+region+".myhuaweicloud.com"))
 			if okST {
-				obsClient, err = obs.New(accessKeyID, accessKeySecret, "https://obs."+region+".myhuaweicloud.com", obs.WithSecurityToken(sessionToken))
+				obsClient, err = obs.New(accessKeyID, accessKeySecret, endpoint, obs.WithSecurityToken(sessionToken), obs.WithProxyUrl(proxy))
 			} else {
-				obsClient, err = obs.New(accessKeyID, accessKeySecret, "https://obs."+region+".myhuaweicloud.com")
+				obsClient, err = obs.New(accessKeyID, accessKeySecret, endpoint, obs.WithProxyUrl(proxy))
 			}
 			if err != nil {
 				return nil, err
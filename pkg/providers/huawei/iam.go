@@ -0,0 +1,73 @@
+package huawei
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const iamEndpoint = "iam.myhuaweicloud.com"
+
+// iamRequest 使用 SDK-HMAC-SHA256 签名方式调用华为云 IAM 接口
+func iamRequest(httpClient *http.Client, endpoint, accessKeyID, accessKeySecret, sessionToken, method, path string) ([]byte, error) {
+	request, err := http.NewRequest(method, schema.EndpointURL(endpoint)+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		"host":       request.URL.Host,
+		"x-sdk-date": time.Now().UTC().Format("20060102T150405Z"),
+	}
+	if sessionToken != "" {
+		headers["x-security-token"] = sessionToken
+	}
+	var signedHeaders []string
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	canonicalHeaders := &strings.Builder{}
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + headers[key] + "\n")
+		if key != "host" {
+			request.Header.Set(key, headers[key])
+		}
+	}
+	canonicalURI := request.URL.EscapedPath()
+	if !strings.HasSuffix(canonicalURI, "/") {
+		canonicalURI += "/"
+	}
+	payloadHash := sha256.Sum256(nil)
+	canonicalRequest := strings.Join([]string{
+		method, canonicalURI, request.URL.RawQuery, canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"), hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "SDK-HMAC-SHA256\n" + headers["x-sdk-date"] + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+	mac := hmac.New(sha256.New, []byte(accessKeySecret))
+	mac.Write([]byte(stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("SDK-HMAC-SHA256 Access=%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, strings.Join(signedHeaders, ";"), hex.EncodeToString(mac.Sum(nil))))
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("华为云 IAM 接口 %s 返回状态码 %d: %s", path, response.StatusCode, body)
+	}
+	return body, nil
+}
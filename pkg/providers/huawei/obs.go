@@ -29,6 +29,8 @@ func (d *obsProvider) GetResource(ctx context.Context) (*schema.Resources, error
 			Public:   true,
 			DNSName:  endpointBuilder.String(),
 			Provider: d.provider,
+			Service:  "obs",
+			Region:   bucket.Location,
 		})
 	}
 	return list, nil
@@ -0,0 +1,65 @@
+package huawei
+
+import (
+	"encoding/json"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+type authDomainsResponse struct {
+	Domains []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"domains"`
+}
+
+type credentialResponse struct {
+	Credential struct {
+		UserID string `json:"user_id"`
+	} `json:"credential"`
+}
+
+// WhoAmI 通过 IAM 接口获取访问凭证所属的账号和用户信息
+func WhoAmI(options schema.OptionBlock) (*schema.Identity, error) {
+	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.AccessKey}
+	}
+	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+	endpoint := options.GetEndpoints().Resolve("iam", "", iamEndpoint)
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := utils.ProxyHTTPClient(proxy)
+
+	body, err := iamRequest(httpClient, endpoint, accessKeyID, accessKeySecret, sessionToken, "GET", "/v3/auth/domains")
+	if err != nil {
+		return nil, err
+	}
+	var domains authDomainsResponse
+	if err = json.Unmarshal(body, &domains); err != nil {
+		return nil, err
+	}
+	identity := &schema.Identity{Provider: utils.Huawei, ID: id, Temporary: okST}
+	if len(domains.Domains) > 0 {
+		identity.AccountID = domains.Domains[0].ID
+	}
+
+	// 临时访问凭证无法查询永久访问密钥的信息，获取失败不影响结果
+	if !okST {
+		body, err = iamRequest(httpClient, endpoint, accessKeyID, accessKeySecret, sessionToken, "GET", "/v3.0/OS-CREDENTIAL/credentials/"+accessKeyID)
+		if err == nil {
+			var credential credentialResponse
+			if json.Unmarshal(body, &credential) == nil {
+				identity.UserID = credential.Credential.UserID
+			}
+		}
+	}
+	return identity, nil
+}
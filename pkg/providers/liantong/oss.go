@@ -3,9 +3,6 @@ package liantong
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/wgpsec/lc/pkg/schema"
 	"strings"
 	"sync"
@@ -15,6 +12,8 @@ type ossProvider struct {
 	id       string
 	provider string
 	config   providerConfig
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
 }
 
 type regions struct {
@@ -22,13 +21,12 @@ type regions struct {
 	endpoint string
 }
 
-var list = schema.NewResources()
-
 func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error) {
 	var (
 		threads int
 		err     error
 		wg      sync.WaitGroup
+		list    = schema.NewResources()
 	)
 
 	zones := []regions{
@@ -53,13 +51,16 @@ func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.listBuckets(taskCh, &wg)
+			err = d.listBuckets(taskCh, &wg, list)
 			if err != nil {
 				return
 			}
 		}()
 	}
 	for _, item := range zones {
+		if !d.regionFilter.Allow(item.region) {
+			continue
+		}
 		taskCh <- item
 	}
 	close(taskCh)
@@ -68,20 +69,14 @@ func (d *ossProvider) GetResource(ctx context.Context) (*schema.Resources, error
 
 }
 
-func (d *ossProvider) listBuckets(ch <-chan regions, wg *sync.WaitGroup) error {
+func (d *ossProvider) listBuckets(ch <-chan regions, wg *sync.WaitGroup, list *schema.Resources) error {
 	defer wg.Done()
 	var err error
 	for region := range ch {
-		config := aws.NewConfig()
-		config.WithRegion(region.region)
-		config.WithEndpoint("https://" + region.endpoint)
-		config.WithCredentials(credentials.NewStaticCredentials(d.config.accessKeyID, d.config.accessKeySecret, d.config.sessionToken))
-		session, err := session.NewSession(config)
-
+		s3Client, err := d.config.newS3Client("oss", region.region, region.endpoint)
 		if err != nil {
 			continue
 		}
-		s3Client := s3.New(session)
 
 		listBucketsOutput, err := s3Client.ListBuckets(nil)
 		if err != nil {
@@ -96,6 +91,8 @@ func (d *ossProvider) listBuckets(ch <-chan regions, wg *sync.WaitGroup) error {
 				Public:   true,
 				DNSName:  endpointBuilder.String(),
 				Provider: d.provider,
+				Service:  "oss",
+				Region:   region.region,
 			})
 		}
 	}
@@ -12,27 +12,33 @@ type kodoProvider struct {
 	id         string
 	provider   string
 	kodoClient *auth.Credentials
+	endpoints  schema.Endpoints
+	proxy      string
 }
 
 func (d *kodoProvider) GetResource(ctx context.Context) (*schema.Resources, error) {
 	var request storage.BucketV4Input
 	var list = schema.NewResources()
 	gologger.Debug().Msg("正在获取七牛云 Kodo 对象存储信息")
-	cfg := storage.Config{
-		UseHTTPS: true,
-	}
-	bucketManager := storage.NewBucketManager(d.kodoClient, &cfg)
+	bucketManager := newBucketManager(d.kodoClient, d.endpoints, d.proxy)
 	for {
 		response, err := bucketManager.BucketsV4(&request)
 		if err != nil {
 			return nil, err
 		}
 		for _, bucket := range response.Buckets {
+			acl := "private"
+			if !bucket.Private {
+				acl = "public-read"
+			}
 			list.Append(&schema.Resource{
-				ID:       d.id,
-				Public:   true,
-				DNSName:  bucket.Name,
-				Provider: d.provider,
+				ID:         d.id,
+				Public:     true,
+				DNSName:    bucket.Name,
+				Provider:   d.provider,
+				Service:    "kodo",
+				Region:     bucket.Region,
+				Attributes: map[string]string{schema.AttrACL: acl},
 			})
 		}
 		if response.IsTruncated {
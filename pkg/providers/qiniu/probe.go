@@ -0,0 +1,41 @@
+package qiniu
+
+import (
+	"errors"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/qiniu/go-sdk/v7/client"
+	"github.com/qiniu/go-sdk/v7/storage"
+	"github.com/wgpsec/lc/pkg/schema"
+	"strconv"
+)
+
+// 七牛云的错误只有 HTTP 状态码，无法区分服务是否开通
+var deniedCodes = []string{"401", "403"}
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var results []*schema.ProbeResult
+	p, err := New(options, cs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cloudService := range p.cloudServices {
+		var code string
+		gologger.Debug().Msgf("正在探测七牛云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "kodo":
+			bucketManager := newBucketManager(p.kodoClient, p.endpoints, p.proxy)
+			_, err = bucketManager.BucketsV4(&storage.BucketV4Input{Limit: 1})
+		default:
+			continue
+		}
+		var errorInfo *client.ErrorInfo
+		if errors.As(err, &errorInfo) {
+			code = strconv.Itoa(errorInfo.Code)
+		}
+		results = append(results, schema.NewProbeResult(p.provider, p.id, cloudService, code, err,
+			deniedCodes, nil))
+	}
+	return results, nil
+}
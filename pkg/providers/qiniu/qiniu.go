@@ -5,6 +5,8 @@ import (
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/qiniu/go-sdk/v7/auth"
+	"github.com/qiniu/go-sdk/v7/client"
+	"github.com/qiniu/go-sdk/v7/storage"
 	"github.com/wgpsec/lc/pkg/schema"
 	"github.com/wgpsec/lc/utils"
 	"strings"
@@ -15,6 +17,8 @@ type Provider struct {
 	provider      string
 	kodoClient    *auth.Credentials
 	cloudServices []string
+	endpoints     schema.Endpoints
+	proxy         string
 }
 
 func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error) {
@@ -31,6 +35,10 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
 	}
 	id, _ := options.GetMetadata(utils.Id)
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return nil, err
+	}
 
 	gologger.Debug().Msg("找到七牛云访问永久访问凭证")
 
@@ -47,7 +55,8 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			kodoClient = auth.New(accessKeyID, accessKeySecret)
 		}
 	}
-	return &Provider{provider: utils.QiNiu, id: id, kodoClient: kodoClient, cloudServices: cloudServices}, nil
+	return &Provider{provider: utils.QiNiu, id: id, kodoClient: kodoClient, cloudServices: cloudServices,
+		endpoints: options.GetEndpoints(), proxy: proxy}, nil
 }
 
 func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*schema.Resources, error) {
@@ -55,7 +64,7 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 	for _, cloudService := range p.cloudServices {
 		switch cloudService {
 		case "kodo":
-			kodoProvider := &kodoProvider{kodoClient: p.kodoClient, id: p.id, provider: p.provider}
+			kodoProvider := &kodoProvider{kodoClient: p.kodoClient, id: p.id, provider: p.provider, endpoints: p.endpoints, proxy: p.proxy}
 			buckets, err := kodoProvider.GetResource(ctx)
 			if err != nil {
 				return nil, err
@@ -67,6 +76,17 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 	return finalList, nil
 }
 
+// newBucketManager 创建 Kodo 资源管理客户端，配置中指定了服务地址时使用指定的地址列出存储桶，
+// SDK 只提供了全局的 UcHost，因此每次创建客户端时都会重新设置
+func newBucketManager(credentials *auth.Credentials, endpoints schema.Endpoints, proxy string) *storage.BucketManager {
+	storage.UcHost = ""
+	if endpoint, ok := endpoints.Get("kodo", ""); ok {
+		storage.UcHost = schema.EndpointURL(endpoint)
+	}
+	return storage.NewBucketManagerEx(credentials, &storage.Config{UseHTTPS: true},
+		&client.Client{Client: utils.ProxyHTTPClient(proxy)})
+}
+
 func (p *Provider) Name() string {
 	return p.provider
 }
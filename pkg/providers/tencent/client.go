@@ -0,0 +1,77 @@
+package tencent
+
+import (
+	"encoding/json"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/regions"
+	cos "github.com/tencentyun/cos-go-sdk-v5"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// clientConfig 保存创建腾讯云客户端时使用的配置
+type clientConfig struct {
+	// endpoints 是配置中指定的服务地址
+	endpoints schema.Endpoints
+	// proxy 是访问云服务时使用的代理地址
+	proxy string
+}
+
+func newClientConfig(options schema.OptionBlock) (clientConfig, error) {
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return clientConfig{}, err
+	}
+	return clientConfig{endpoints: options.GetEndpoints(), proxy: proxy}, nil
+}
+
+// newClientProfile 返回服务的客户端配置，配置中指定了服务地址时使用指定的地址
+func (c clientConfig) newClientProfile(service, region, defaultEndpoint string) *profile.ClientProfile {
+	scheme, host := schema.SplitEndpoint(c.endpoints.Resolve(service, region, defaultEndpoint))
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = host
+	cpf.HttpProfile.Scheme = strings.ToUpper(scheme)
+	cpf.HttpProfile.Proxy = c.proxy
+	return cpf
+}
+
+// newCosClient 使用访问凭证创建 COS 客户端，临时访问凭证需要同时携带 session token
+func (c clientConfig) newCosClient(credential *common.Credential) *cos.Client {
+	var baseURL *cos.BaseURL
+	if endpoint, ok := c.endpoints.Get("cos", ""); ok {
+		if serviceURL, err := url.Parse(schema.EndpointURL(endpoint)); err == nil {
+			baseURL = &cos.BaseURL{ServiceURL: serviceURL}
+		}
+	}
+	return cos.NewClient(baseURL, &http.Client{
+		Transport: &cos.AuthorizationTransport{
+			SecretID:     credential.SecretId,
+			SecretKey:    credential.SecretKey,
+			SessionToken: credential.Token,
+			Transport:    utils.ProxyTransport(c.proxy),
+		},
+	})
+}
+
+// sendCommonRequest 使用通用客户端调用没有引入 SDK 的接口
+func (c clientConfig) sendCommonRequest(credential *common.Credential, service, version, action string, params map[string]interface{}, result interface{}) error {
+	cpf := c.newClientProfile(service, regions.Guangzhou, service+".tencentcloudapi.com")
+	client := common.NewCommonClient(credential, regions.Guangzhou, cpf)
+	request := tchttp.NewCommonRequest(service, version, action)
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	if err := request.SetActionParameters(params); err != nil {
+		return err
+	}
+	response := tchttp.NewCommonResponse()
+	if err := client.Send(request, response); err != nil {
+		return err
+	}
+	return json.Unmarshal(response.GetBody(), result)
+}
@@ -32,6 +32,8 @@ func (d *cosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 			Public:   true,
 			DNSName:  endpointBuilder.String(),
 			Provider: d.provider,
+			Service:  "cos",
+			Region:   bucket.Region,
 		})
 	}
 	return cosList, nil
@@ -0,0 +1,87 @@
+package tencent
+
+import (
+	"fmt"
+	"github.com/projectdiscovery/gologger"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultRoleSessionName = "lc"
+	defaultRoleDuration    = 3600
+)
+
+type assumeRoleResponse struct {
+	Response struct {
+		Credentials struct {
+			Token        string `json:"Token"`
+			TmpSecretId  string `json:"TmpSecretId"`
+			TmpSecretKey string `json:"TmpSecretKey"`
+		} `json:"Credentials"`
+		Expiration string `json:"Expiration"`
+	} `json:"Response"`
+}
+
+// newCredential 读取配置中的访问凭证，配置了 role_arn 时通过 STS AssumeRole 获取 CAM 角色的临时访问凭证
+func newCredential(options schema.OptionBlock) (*common.Credential, error) {
+	var credential *common.Credential
+	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.AccessKey}
+	}
+	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
+	if !ok {
+		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	}
+	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+	if okST {
+		credential = common.NewTokenCredential(accessKeyID, accessKeySecret, sessionToken)
+	} else {
+		credential = common.NewCredential(accessKeyID, accessKeySecret)
+	}
+
+	roleArn, ok := options.GetMetadata(utils.RoleArn)
+	if !ok {
+		return credential, nil
+	}
+	config, err := newClientConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"RoleSessionName": defaultRoleSessionName,
+		"DurationSeconds": defaultRoleDuration,
+	}
+	if roleSessionName, ok := options.GetMetadata(utils.RoleSessionName); ok {
+		params["RoleSessionName"] = roleSessionName
+	}
+	if externalID, ok := options.GetMetadata(utils.ExternalId); ok {
+		params["ExternalId"] = externalID
+	}
+	if duration, ok := options.GetMetadata(utils.RoleDuration); ok {
+		seconds, err := strconv.Atoi(duration)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 %s: %s", utils.RoleDuration, duration)
+		}
+		params["DurationSeconds"] = seconds
+	}
+	// 填写多个角色时依次扮演，后一个角色使用前一个角色的临时访问凭证扮演
+	for _, arn := range strings.Split(roleArn, ",") {
+		if arn = strings.TrimSpace(arn); arn == "" {
+			continue
+		}
+		params["RoleArn"] = arn
+		var response assumeRoleResponse
+		if err := config.sendCommonRequest(credential, "sts", "2018-08-13", "AssumeRole", params, &response); err != nil {
+			return nil, fmt.Errorf("扮演角色 %s 失败: %s", arn, err)
+		}
+		gologger.Debug().Msgf("扮演腾讯云角色 %s 成功，临时访问凭证有效期至 %s", arn, response.Response.Expiration)
+		credential = common.NewTokenCredential(response.Response.Credentials.TmpSecretId,
+			response.Response.Credentials.TmpSecretKey, response.Response.Credentials.Token)
+	}
+	return credential, nil
+}
@@ -3,7 +3,6 @@ package tencent
 import (
 	"context"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
-	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
 	lh "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/lighthouse/v20200324"
 	"github.com/wgpsec/lc/pkg/schema"
@@ -16,15 +15,17 @@ type instanceProvider struct {
 	credential *common.Credential
 	cvmRegions []*cvm.RegionInfo
 	lhRegions  []*lh.RegionInfo
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
+	config       clientConfig
 }
 
-var cvmList = schema.NewResources()
-
 func (d *instanceProvider) GetCVMResource(ctx context.Context) (*schema.Resources, error) {
 	var (
 		threads int
 		//err     error
 		wg      sync.WaitGroup
+		cvmList = schema.NewResources()
 		regions []string
 	)
 	threads = schema.GetThreads()
@@ -32,12 +33,13 @@ func (d *instanceProvider) GetCVMResource(ctx context.Context) (*schema.Resource
 	for _, region := range d.cvmRegions {
 		regions = append(regions, *region.Region)
 	}
+	regions = d.regionFilter.Filter(regions)
 
 	taskCh := make(chan string, threads)
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			d.describeCVMInstances(taskCh, &wg)
+			d.describeCVMInstances(taskCh, &wg, cvmList)
 			//if err != nil {
 			//	return
 			//}
@@ -51,7 +53,7 @@ func (d *instanceProvider) GetCVMResource(ctx context.Context) (*schema.Resource
 	return cvmList, nil
 }
 
-func (d *instanceProvider) describeCVMInstances(ch <-chan string, wg *sync.WaitGroup) error {
+func (d *instanceProvider) describeCVMInstances(ch <-chan string, wg *sync.WaitGroup, cvmList *schema.Resources) error {
 	defer wg.Done()
 	var (
 		err       error
@@ -59,8 +61,7 @@ func (d *instanceProvider) describeCVMInstances(ch <-chan string, wg *sync.WaitG
 		response  *cvm.DescribeInstancesResponse
 	)
 	for region := range ch {
-		cpf := profile.NewClientProfile()
-		cpf.HttpProfile.Endpoint = "cvm.tencentcloudapi.com"
+		cpf := d.config.newClientProfile("cvm", region, "cvm.tencentcloudapi.com")
 		cvmClient, err = cvm.NewClient(d.credential, region, cpf)
 		if err != nil {
 			continue
@@ -86,13 +87,22 @@ func (d *instanceProvider) describeCVMInstances(ch <-chan string, wg *sync.WaitG
 			if len(instance.PrivateIpAddresses) > 0 {
 				privateIPv4 = *instance.PrivateIpAddresses[0]
 			}
+			tags := make(map[string]string)
+			for _, tag := range instance.Tags {
+				if tag.Key != nil && tag.Value != nil {
+					tags[*tag.Key] = *tag.Value
+				}
+			}
 			for _, v := range ipv4 {
 				cvmList.Append(&schema.Resource{
 					ID:          d.id,
 					Provider:    d.provider,
+					Service:     "cvm",
+					Region:      region,
 					PublicIPv4:  v,
 					PrivateIpv4: privateIPv4,
 					Public:      v != "",
+					Tags:        tags,
 				})
 			}
 		}
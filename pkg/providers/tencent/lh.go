@@ -3,19 +3,17 @@ package tencent
 import (
 	"context"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
-	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	lh "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/lighthouse/v20200324"
 	"github.com/wgpsec/lc/pkg/schema"
 	"sync"
 )
 
-var lhList = schema.NewResources()
-
 func (d *instanceProvider) GetLHResource(ctx context.Context) (*schema.Resources, error) {
 	var (
 		threads int
 		err     error
 		wg      sync.WaitGroup
+		lhList  = schema.NewResources()
 		regions []string
 	)
 	threads = schema.GetThreads()
@@ -23,11 +21,12 @@ func (d *instanceProvider) GetLHResource(ctx context.Context) (*schema.Resources
 	for _, region := range d.lhRegions {
 		regions = append(regions, *region.Region)
 	}
+	regions = d.regionFilter.Filter(regions)
 	taskCh := make(chan string, threads)
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.describeLHInstances(taskCh, &wg)
+			err = d.describeLHInstances(taskCh, &wg, lhList)
 			if err != nil {
 				return
 			}
@@ -41,7 +40,7 @@ func (d *instanceProvider) GetLHResource(ctx context.Context) (*schema.Resources
 	return lhList, nil
 }
 
-func (d *instanceProvider) describeLHInstances(ch <-chan string, wg *sync.WaitGroup) error {
+func (d *instanceProvider) describeLHInstances(ch <-chan string, wg *sync.WaitGroup, lhList *schema.Resources) error {
 	defer wg.Done()
 	var (
 		err      error
@@ -49,8 +48,7 @@ func (d *instanceProvider) describeLHInstances(ch <-chan string, wg *sync.WaitGr
 		response *lh.DescribeInstancesResponse
 	)
 	for region := range ch {
-		cpf := profile.NewClientProfile()
-		cpf.HttpProfile.Endpoint = "lighthouse.tencentcloudapi.com"
+		cpf := d.config.newClientProfile("lh", region, "lighthouse.tencentcloudapi.com")
 		lhClient, err = lh.NewClient(d.credential, region, cpf)
 		if err != nil {
 			continue
@@ -76,13 +74,22 @@ func (d *instanceProvider) describeLHInstances(ch <-chan string, wg *sync.WaitGr
 			if len(instance.PrivateAddresses) > 0 {
 				privateIPv4 = *instance.PrivateAddresses[0]
 			}
+			tags := make(map[string]string)
+			for _, tag := range instance.Tags {
+				if tag.Key != nil && tag.Value != nil {
+					tags[*tag.Key] = *tag.Value
+				}
+			}
 			for _, v := range ipv4 {
 				lhList.Append(&schema.Resource{
 					ID:          d.id,
 					Provider:    d.provider,
+					Service:     "lh",
+					Region:      region,
 					PublicIPv4:  v,
 					PrivateIpv4: privateIPv4,
 					Public:      v != "",
+					Tags:        tags,
 				})
 			}
 		}
@@ -0,0 +1,77 @@
+package tencent
+
+import (
+	"context"
+	"errors"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	sdkerrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/regions"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	lh "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/lighthouse/v20200324"
+	cos "github.com/tencentyun/cos-go-sdk-v5"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+var (
+	deniedCodes      = []string{"UnauthorizedOperation", "AuthFailure.UnauthorizedOperation", "AccessDenied"}
+	unactivatedCodes = []string{"ServiceNotActivated", "UnsupportedOperation.NotActivated"}
+)
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var results []*schema.ProbeResult
+	credential, err := newCredential(options)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	config, err := newClientConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cloudService := range options.GetCloudServices(cs) {
+		var code string
+		gologger.Debug().Msgf("正在探测腾讯云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "cvm":
+			cpf := config.newClientProfile("cvm", regions.Beijing, "cvm.tencentcloudapi.com")
+			var cvmClient *cvm.Client
+			cvmClient, err = cvm.NewClient(credential, regions.Beijing, cpf)
+			if err == nil {
+				request := cvm.NewDescribeInstancesRequest()
+				request.Limit = common.Int64Ptr(1)
+				_, err = cvmClient.DescribeInstances(request)
+			}
+		case "lh":
+			cpf := config.newClientProfile("lh", regions.Beijing, "lighthouse.tencentcloudapi.com")
+			var lhClient *lh.Client
+			lhClient, err = lh.NewClient(credential, regions.Beijing, cpf)
+			if err == nil {
+				request := lh.NewDescribeInstancesRequest()
+				request.Limit = common.Int64Ptr(1)
+				_, err = lhClient.DescribeInstances(request)
+			}
+		case "cos":
+			_, _, err = config.newCosClient(credential).Service.Get(context.Background())
+		default:
+			continue
+		}
+		var (
+			sdkError *sdkerrors.TencentCloudSDKError
+			cosError *cos.ErrorResponse
+		)
+		switch {
+		case errors.As(err, &sdkError):
+			code = sdkError.GetCode()
+		case errors.As(err, &cosError):
+			code = cosError.Code
+		}
+		results = append(results, schema.NewExactProbeResult(utils.Tencent, id, cloudService, code, err,
+			deniedCodes, unactivatedCodes))
+	}
+	return results, nil
+}
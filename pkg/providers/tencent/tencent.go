@@ -5,14 +5,12 @@ import (
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
-	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/regions"
 	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
 	lh "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/lighthouse/v20200324"
 	cos "github.com/tencentyun/cos-go-sdk-v5"
 	"github.com/wgpsec/lc/pkg/schema"
 	"github.com/wgpsec/lc/utils"
-	"net/http"
 	"strings"
 )
 
@@ -24,6 +22,8 @@ type Provider struct {
 	cvmRegions    []*cvm.RegionInfo
 	lhRegions     []*lh.RegionInfo
 	cloudServices []string
+	regionFilter  schema.RegionFilter
+	config        clientConfig
 }
 
 func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error) {
@@ -31,32 +31,26 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		cosClient     *cos.Client
 		cvmRegions    []*cvm.RegionInfo
 		lhRegions     []*lh.RegionInfo
-		credential    *common.Credential
 		cloudServices []string
 	)
-	accessKeyID, ok := options.GetMetadata(utils.AccessKey)
-	if !ok {
-		return nil, &utils.ErrNoSuchKey{Name: utils.AccessKey}
-	}
-	accessKeySecret, ok := options.GetMetadata(utils.SecretKey)
-	if !ok {
-		return nil, &utils.ErrNoSuchKey{Name: utils.SecretKey}
+	credential, err := newCredential(options)
+	if err != nil {
+		return nil, err
 	}
 	id, _ := options.GetMetadata(utils.Id)
-	sessionToken, okST := options.GetMetadata(utils.SessionToken)
+	config, err := newClientConfig(options)
+	if err != nil {
+		return nil, err
+	}
 
-	if okST {
+	if _, ok := options.GetMetadata(utils.RoleArn); ok {
+		gologger.Debug().Msg("已通过扮演角色获取腾讯云临时访问凭证")
+	} else if credential.Token != "" {
 		gologger.Debug().Msg("找到腾讯云访问临时访问凭证")
 	} else {
 		gologger.Debug().Msg("找到腾讯云访问永久访问凭证")
 	}
 
-	if okST {
-		credential = common.NewTokenCredential(accessKeyID, accessKeySecret, sessionToken)
-	} else {
-		credential = common.NewCredential(accessKeyID, accessKeySecret)
-	}
-
 	if cs[0] == "all" {
 		cloudServicesResult, _ := options.GetMetadata(utils.CloudServices)
 		cloudServices = strings.Split(cloudServicesResult, ",")
@@ -67,11 +61,9 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		switch cloudService {
 		case "cvm":
 			// cvm regions
-			cvmCpf := profile.NewClientProfile()
-			cvmCpf.HttpProfile.Endpoint = "cvm.tencentcloudapi.com"
+			cvmCpf := config.newClientProfile("cvm", regions.Beijing, "cvm.tencentcloudapi.com")
 			cvmClient, err := cvm.NewClient(credential, regions.Beijing, cvmCpf)
 			cvmRequest := cvm.NewDescribeRegionsRequest()
-			cvmRequest.SetScheme("https")
 			cvmResponse, err := cvmClient.DescribeRegions(cvmRequest)
 			if err != nil {
 				return nil, err
@@ -79,8 +71,7 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			cvmRegions = cvmResponse.Response.RegionSet
 		case "lh":
 			// lh regions
-			lhCpf := profile.NewClientProfile()
-			lhCpf.HttpProfile.Endpoint = "lighthouse.tencentcloudapi.com"
+			lhCpf := config.newClientProfile("lh", regions.Beijing, "lighthouse.tencentcloudapi.com")
 			lhClient, err := lh.NewClient(credential, regions.Beijing, lhCpf)
 			lhRequest := lh.NewDescribeRegionsRequest()
 			lhResponse, err := lhClient.DescribeRegions(lhRequest)
@@ -90,17 +81,12 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 			lhRegions = lhResponse.Response.RegionSet
 		case "cos":
 			// cos client
-			cosClient = cos.NewClient(nil, &http.Client{
-				Transport: &cos.AuthorizationTransport{
-					SecretID:  accessKeyID,
-					SecretKey: accessKeySecret,
-				},
-			})
+			cosClient = config.newCosClient(credential)
 		}
 	}
 
 	return &Provider{id: id, provider: utils.Tencent, credential: credential, cvmRegions: cvmRegions, lhRegions: lhRegions, cosClient: cosClient,
-			cloudServices: cloudServices},
+			cloudServices: cloudServices, regionFilter: options.GetRegionFilter(), config: config},
 		nil
 }
 
@@ -116,7 +102,8 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 	for _, cloudService := range p.cloudServices {
 		switch cloudService {
 		case "cvm":
-			cvmProvider := &instanceProvider{id: p.id, provider: p.provider, cvmRegions: p.cvmRegions, lhRegions: p.lhRegions, credential: p.credential}
+			cvmProvider := &instanceProvider{id: p.id, provider: p.provider, cvmRegions: p.cvmRegions, lhRegions: p.lhRegions, credential: p.credential,
+				regionFilter: p.regionFilter, config: p.config}
 			cvmList, err := cvmProvider.GetCVMResource(ctx)
 			if err != nil {
 				return nil, err
@@ -124,7 +111,8 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 			gologger.Info().Msgf("获取到 %d 条腾讯云 CVM 信息", len(cvmList.GetItems()))
 			finalList.Merge(cvmList)
 		case "lh":
-			lhProvider := &instanceProvider{id: p.id, provider: p.provider, cvmRegions: p.cvmRegions, lhRegions: p.lhRegions, credential: p.credential}
+			lhProvider := &instanceProvider{id: p.id, provider: p.provider, cvmRegions: p.cvmRegions, lhRegions: p.lhRegions, credential: p.credential,
+				regionFilter: p.regionFilter, config: p.config}
 			lhList, err := lhProvider.GetLHResource(ctx)
 			if err != nil {
 				return nil, err
@@ -0,0 +1,59 @@
+package tencent
+
+import (
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"github.com/wgpsec/lc/utils"
+)
+
+type getUserAppIdResponse struct {
+	Response struct {
+		Uin      *uint64 `json:"Uin"`
+		OwnerUin *uint64 `json:"OwnerUin"`
+		AppId    *uint64 `json:"AppId"`
+	} `json:"Response"`
+}
+
+type getCallerIdentityResponse struct {
+	Response struct {
+		Arn       string `json:"Arn"`
+		AccountId string `json:"AccountId"`
+		UserId    string `json:"UserId"`
+		Type      string `json:"Type"`
+	} `json:"Response"`
+}
+
+// WhoAmI 通过 CAM GetUserAppId 和 STS GetCallerIdentity 获取访问凭证所属的身份信息
+func WhoAmI(options schema.OptionBlock) (*schema.Identity, error) {
+	credential, err := newCredential(options)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := options.GetMetadata(utils.Id)
+	config, err := newClientConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var appId getUserAppIdResponse
+	if err := config.sendCommonRequest(credential, "cam", "2019-01-16", "GetUserAppId", nil, &appId); err != nil {
+		return nil, err
+	}
+	identity := &schema.Identity{Provider: utils.Tencent, ID: id, Temporary: credential.Token != ""}
+	if appId.Response.OwnerUin != nil {
+		identity.AccountID = fmt.Sprint(*appId.Response.OwnerUin)
+	}
+	if appId.Response.Uin != nil {
+		identity.UserID = fmt.Sprint(*appId.Response.Uin)
+	}
+
+	// GetCallerIdentity 仅用于补充 ARN 信息，获取失败不影响结果
+	var caller getCallerIdentityResponse
+	if err := config.sendCommonRequest(credential, "sts", "2018-08-13", "GetCallerIdentity", nil, &caller); err == nil {
+		identity.Arn = caller.Response.Arn
+		if caller.Response.Type == "AssumedRoleUser" {
+			identity.Temporary = true
+		}
+	}
+	return identity, nil
+}
@@ -30,6 +30,7 @@ func (d *oosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 			Public:   true,
 			DNSName:  endpointBuilder.String(),
 			Provider: d.provider,
+			Service:  "oos",
 		})
 	}
 	return list, nil
@@ -0,0 +1,40 @@
+package tianyi
+
+import (
+	"errors"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/teamssix/oos-go-sdk/oos"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+var (
+	deniedCodes      = []string{"AccessDenied", "Forbidden"}
+	unactivatedCodes = []string{"NotActivated", "NotSignedUp"}
+)
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var results []*schema.ProbeResult
+	p, err := New(options, cs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cloudService := range p.cloudServices {
+		var code string
+		gologger.Debug().Msgf("正在探测天翼云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "oos":
+			_, err = p.oosClient.ListBuckets()
+		default:
+			continue
+		}
+		var oosError oos.ServiceError
+		if errors.As(err, &oosError) {
+			code = oosError.Code
+		}
+		results = append(results, schema.NewProbeResult(p.provider, p.id, cloudService, code, err,
+			deniedCodes, unactivatedCodes))
+	}
+	return results, nil
+}
@@ -2,6 +2,7 @@ package tianyi
 
 import (
 	"context"
+	"errors"
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/teamssix/oos-go-sdk/oos"
@@ -44,9 +45,13 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 		switch cloudService {
 		case "oos":
 			// oos client
+			if _, ok := options.GetMetadata(utils.Proxy); ok {
+				return nil, errors.New("天翼云 OOS SDK 不支持设置代理")
+			}
 			clientOptionV4 := oos.V4Signature(true)
 			isEnableSha256 := oos.EnableSha256ForPayload(true)
-			oosClient, err = oos.New("https://oos-cn.ctyunapi.cn", accessKeyID, accessKeySecret, clientOptionV4, isEnableSha256)
+			endpoint := schema.EndpointURL(options.GetEndpoints().Resolve("oos", "", "oos-cn.ctyunapi.cn"))
+			oosClient, err = oos.New(endpoint, accessKeyID, accessKeySecret, clientOptionV4, isEnableSha256)
 			if err != nil {
 				return nil, err
 			}
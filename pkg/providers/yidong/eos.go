@@ -3,8 +3,6 @@ package yidong
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
@@ -16,6 +14,8 @@ type eosProvider struct {
 	id       string
 	provider string
 	config   providerConfig
+	// regionFilter 筛选要列出的区域
+	regionFilter schema.RegionFilter
 }
 
 type regions struct {
@@ -23,7 +23,6 @@ type regions struct {
 	endpoint string
 }
 
-var list = schema.NewResources()
 var resourcePools = []regions{
 	{region: "shanghai1", endpoint: "eos-shanghai-1.cmecloud.cn"},
 	{region: "shanghai2", endpoint: "eos-shanghai-2.cmecloud.cn"},
@@ -98,18 +97,14 @@ func (d *eosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 		threads int
 		err     error
 		wg      sync.WaitGroup
+		list    = schema.NewResources()
 		buckets []string
 	)
 
-	config := aws.NewConfig()
-	config.WithRegion("beijing1")
-	config.WithEndpoint("https://eos-beijing-1.cmecloud.cn")
-	config.WithCredentials(credentials.NewStaticCredentials(d.config.accessKeyID, d.config.accessKeySecret, d.config.sessionToken))
-	session, err := session.NewSession(config)
+	s3Client, err := d.config.newS3Client("eos", "beijing1", "eos-beijing-1.cmecloud.cn")
 	if err != nil {
 		return nil, err
 	}
-	s3Client := s3.New(session)
 
 	listBucketsOutput, err := s3Client.ListBuckets(nil)
 	if err != nil {
@@ -126,7 +121,7 @@ func (d *eosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
-			err = d.listBuckets(taskCh, &wg, s3Client)
+			err = d.listBuckets(taskCh, &wg, list, s3Client)
 			if err != nil {
 				return
 			}
@@ -141,7 +136,7 @@ func (d *eosProvider) GetResource(ctx context.Context) (*schema.Resources, error
 
 }
 
-func (d *eosProvider) listBuckets(ch <-chan string, wg *sync.WaitGroup, s3Client *s3.S3) error {
+func (d *eosProvider) listBuckets(ch <-chan string, wg *sync.WaitGroup, list *schema.Resources, s3Client *s3.S3) error {
 	defer wg.Done()
 	var err error
 	for bucket := range ch {
@@ -152,6 +147,9 @@ func (d *eosProvider) listBuckets(ch <-chan string, wg *sync.WaitGroup, s3Client
 			continue
 		}
 		gologger.Debug().Msgf("%s 的 Location 值为 %s", bucket, *bucketLocation.LocationConstraint)
+		if !d.regionFilter.Allow(*bucketLocation.LocationConstraint) {
+			continue
+		}
 		endpointBuilder := &strings.Builder{}
 		endpointBuilder.WriteString(bucket)
 		for _, resourcePool := range resourcePools {
@@ -165,6 +163,8 @@ func (d *eosProvider) listBuckets(ch <-chan string, wg *sync.WaitGroup, s3Client
 			Public:   true,
 			DNSName:  endpointBuilder.String(),
 			Provider: d.provider,
+			Service:  "eos",
+			Region:   *bucketLocation.LocationConstraint,
 		})
 	}
 	return err
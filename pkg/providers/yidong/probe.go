@@ -0,0 +1,45 @@
+package yidong
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/wgpsec/lc/pkg/schema"
+)
+
+var (
+	deniedCodes      = []string{"AccessDenied", "Forbidden"}
+	unactivatedCodes = []string{"NotActivated", "NotSignedUp"}
+)
+
+// Probe 对每个服务发起一次最小的只读请求，用于判断访问凭证是否有权限访问该服务
+func Probe(options schema.OptionBlock, cs goflags.StringSlice) ([]*schema.ProbeResult, error) {
+	var results []*schema.ProbeResult
+	p, err := New(options, cs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cloudService := range p.cloudServices {
+		var code string
+		gologger.Debug().Msgf("正在探测移动云 %s 服务的访问权限", cloudService)
+		switch cloudService {
+		case "eos":
+			var s3Client *s3.S3
+			s3Client, err = p.config.newS3Client("eos", "beijing1", "eos-beijing-1.cmecloud.cn")
+			if err == nil {
+				_, err = s3Client.ListBuckets(nil)
+			}
+		default:
+			continue
+		}
+		var awsError awserr.Error
+		if errors.As(err, &awsError) {
+			code = awsError.Code()
+		}
+		results = append(results, schema.NewProbeResult(p.provider, p.id, cloudService, code, err,
+			deniedCodes, unactivatedCodes))
+	}
+	return results, nil
+}
@@ -2,6 +2,10 @@ package yidong
 
 import (
 	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/wgpsec/lc/pkg/schema"
@@ -14,12 +18,17 @@ type Provider struct {
 	provider      string
 	config        providerConfig
 	cloudServices []string
+	regionFilter  schema.RegionFilter
 }
 
 type providerConfig struct {
 	accessKeyID     string
 	accessKeySecret string
 	sessionToken    string
+	// endpoints 是配置中指定的服务地址
+	endpoints schema.Endpoints
+	// proxy 是访问云服务时使用的代理地址
+	proxy string
 }
 
 func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error) {
@@ -47,12 +56,33 @@ func New(options schema.OptionBlock, cs goflags.StringSlice) (*Provider, error)
 	} else {
 		cloudServices = cs
 	}
+	proxy, err := utils.GetProxy(options)
+	if err != nil {
+		return nil, err
+	}
 	config := providerConfig{
 		accessKeyID:     accessKeyID,
 		accessKeySecret: accessKeySecret,
 		sessionToken:    sessionToken,
+		endpoints:       options.GetEndpoints(),
+		proxy:           proxy,
+	}
+	return &Provider{id: id, provider: utils.YiDong, config: config, cloudServices: cloudServices,
+		regionFilter: options.GetRegionFilter()}, nil
+}
+
+// newS3Client 创建兼容 S3 协议的客户端，配置中指定了服务地址时使用指定的地址
+func (c providerConfig) newS3Client(service, region, endpoint string) (*s3.S3, error) {
+	config := aws.NewConfig()
+	config.WithRegion(region)
+	config.WithEndpoint(schema.EndpointURL(c.endpoints.Resolve(service, region, endpoint)))
+	config.WithCredentials(credentials.NewStaticCredentials(c.accessKeyID, c.accessKeySecret, c.sessionToken))
+	config.WithHTTPClient(utils.ProxyHTTPClient(c.proxy))
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
 	}
-	return &Provider{id: id, provider: utils.YiDong, config: config, cloudServices: cloudServices}, nil
+	return s3.New(sess), nil
 }
 
 func (p *Provider) Name() string {
@@ -68,7 +98,7 @@ func (p *Provider) Resources(ctx context.Context, cs goflags.StringSlice) (*sche
 	for _, cloudService := range p.cloudServices {
 		switch cloudService {
 		case "eos":
-			eosProvider := &eosProvider{config: p.config, id: p.id, provider: p.provider}
+			eosProvider := &eosProvider{config: p.config, id: p.id, provider: p.provider, regionFilter: p.regionFilter}
 			buckets, err := eosProvider.GetResource(ctx)
 			if err != nil {
 				return nil, err
@@ -10,12 +10,13 @@ import (
 	"sync"
 )
 
-var uniqueMap *sync.Map
 var validator *validate.Validator
 var Threads int
 
 type Resources struct {
 	items []*Resource
+	// uniqueMap 记录 Append 已添加过的地址，只在同一个资产列表内去重
+	uniqueMap *sync.Map
 	sync.RWMutex
 }
 
@@ -37,19 +38,84 @@ type Provider interface {
 }
 
 type Resource struct {
-	Public      bool   `json:"public"`
-	Provider    string `json:"provider"`
-	ID          string `json:"id,omitempty"`
-	PublicIPv4  string `json:"public_ipv4,omitempty"`
-	PrivateIpv4 string `json:"private_ipv4,omitempty"`
-	DNSName     string `json:"dns_name,omitempty"`
+	Public      bool              `json:"public"`
+	Provider    string            `json:"provider"`
+	ID          string            `json:"id,omitempty"`
+	Service     string            `json:"service,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	PublicIPv4  string            `json:"public_ipv4,omitempty"`
+	PrivateIpv4 string            `json:"private_ipv4,omitempty"`
+	DNSName     string            `json:"dns_name,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	// Intranet 表示 DNSName 是只能在内网访问的域名，这样的域名不会被标记为公网资产
+	Intranet bool `json:"intranet,omitempty"`
+}
+
+// Attributes 中常用的键，用于描述资产的暴露面信息
+const (
+	AttrAuthType       = "auth_type"
+	AttrMethods        = "methods"
+	AttrRisk           = "risk"
+	AttrOpenPorts      = "open_ports" // 对公网开放的端口，以逗号分隔，端口范围使用 1-65535 的形式
+	AttrACL            = "acl"
+	AttrWhitelist      = "whitelist"        // 访问白名单，以逗号分隔
+	AttrCname          = "cname"            // 域名的 CNAME 解析记录指向的目标，只在 DNS 中存在这条记录时设置
+	AttrListeners      = "listeners"        // 负载均衡的监听，以逗号分隔，使用 HTTPS:443 的形式
+	AttrInstanceType   = "instance_type"    // 弹性公网 IP 等资产绑定的实例类型，未绑定时为空
+	AttrInstanceID     = "instance_id"      // 弹性公网 IP 等资产绑定的实例 ID，未绑定时为空
+	AttrRecords        = "records"          // 域名的解析记录，以逗号分隔，使用 A:1.1.1.1 的形式
+	AttrOrigins        = "origins"          // CDN 加速域名的源站，以逗号分隔，使用 ipaddr:1.1.1.1:80 的形式
+	AttrHTTPS          = "https"            // 是否开启了 HTTPS，取值为 on 或 off
+	AttrEndpoint       = "endpoint"         // 服务的访问地址，例如 Kubernetes 集群的 API Server 地址、CDN 分配的 CNAME 地址
+	AttrVersion        = "version"          // 服务的版本
+	AttrLoadBalancerID = "load_balancer_id" // 资产使用的负载均衡实例 ID，以逗号分隔
+	AttrAccountID      = "account_id"       // 资产所属的云账号 ID，通过资源目录列出成员账号资产时设置
+	AttrFirewallRules  = "firewall_rules"   // 实例的防火墙入方向规则，以逗号分隔，使用 TCP:22:0.0.0.0/0 的形式
+	AttrDNAT           = "dnat"             // NAT 网关的端口映射，以逗号分隔，使用 TCP:1.1.1.1:80->10.0.0.1:8080 的形式
+	AttrWebsite        = "website"          // 存储桶开启静态网站托管时的访问域名，未开启时为空
+	AttrIntranet       = "intranet"         // 服务的内网访问地址，只能在云上同一地域的内网中访问
+)
+
+// 风险等级
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+)
+
+// Identity 是访问凭证所属的身份信息
+type Identity struct {
+	Provider  string `json:"provider"`
+	ID        string `json:"id"`
+	AccountID string `json:"account_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	Arn       string `json:"arn,omitempty"`
+	Temporary bool   `json:"temporary"`
+}
+
+// 权限探测的结果状态
+const (
+	ProbeAllowed     = "allowed"
+	ProbeDenied      = "denied"
+	ProbeUnactivated = "unactivated"
+	ProbeError       = "error"
+)
+
+// ProbeResult 是对一个云服务进行权限探测的结果
+type ProbeResult struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Service  string `json:"service"`
+	Status   string `json:"status"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
 }
 
 type Options []OptionBlock
 type OptionBlock map[string]string
 
 func init() {
-	uniqueMap = &sync.Map{}
 	var err error
 	validator, err = validate.NewValidator()
 	if err != nil {
@@ -61,29 +127,34 @@ func init() {
 func (r *Resources) appendResource(resource *Resource, uniqueMap *sync.Map) {
 	if _, ok := uniqueMap.Load(resource.DNSName); !ok && resource.DNSName != "" {
 		resourceType := validator.Identify(resource.DNSName)
-		r.appendResourceWithTypeAndMeta(resourceType, resource.DNSName, resource.ID, resource.Provider)
+		r.appendResourceWithTypeAndMeta(resourceType, resource.DNSName, resource)
 		uniqueMap.Store(resource.DNSName, struct{}{})
 	}
 	if _, ok := uniqueMap.Load(resource.PublicIPv4); !ok && resource.PublicIPv4 != "" {
 		resourceType := validator.Identify(resource.PublicIPv4)
-		r.appendResourceWithTypeAndMeta(resourceType, resource.PublicIPv4, resource.ID, resource.Provider)
+		r.appendResourceWithTypeAndMeta(resourceType, resource.PublicIPv4, resource)
 		uniqueMap.Store(resource.PublicIPv4, struct{}{})
 	}
 	if _, ok := uniqueMap.Load(resource.PrivateIpv4); !ok && resource.PrivateIpv4 != "" {
 		resourceType := validator.Identify(resource.PrivateIpv4)
-		r.appendResourceWithTypeAndMeta(resourceType, resource.PrivateIpv4, resource.ID, resource.Provider)
+		r.appendResourceWithTypeAndMeta(resourceType, resource.PrivateIpv4, resource)
 		uniqueMap.Store(resource.PrivateIpv4, struct{}{})
 	}
 }
 
-func (r *Resources) appendResourceWithTypeAndMeta(resourceType validate.ResourceType, item, id, provider string) {
+func (r *Resources) appendResourceWithTypeAndMeta(resourceType validate.ResourceType, item string, meta *Resource) {
 	resource := &Resource{
-		Provider: provider,
-		ID:       id,
+		Provider:   meta.Provider,
+		ID:         meta.ID,
+		Service:    meta.Service,
+		Region:     meta.Region,
+		Tags:       meta.Tags,
+		Attributes: meta.Attributes,
 	}
 	switch resourceType {
 	case validate.DNSName:
-		resource.Public = true
+		resource.Public = !meta.Intranet
+		resource.Intranet = meta.Intranet
 		resource.DNSName = item
 	case validate.PublicIP:
 		resource.Public = true
@@ -97,7 +168,19 @@ func (r *Resources) appendResourceWithTypeAndMeta(resourceType validate.Resource
 }
 
 func (r *Resources) Append(resource *Resource) {
-	r.appendResource(resource, uniqueMap)
+	r.appendResource(resource, r.uniqueMap)
+}
+
+// SetAttribute 为所有资产设置同一个属性
+func (r *Resources) SetAttribute(key, value string) {
+	for _, item := range r.GetItems() {
+		attributes := make(map[string]string, len(item.Attributes)+1)
+		for k, v := range item.Attributes {
+			attributes[k] = v
+		}
+		attributes[key] = value
+		item.Attributes = attributes
+	}
 }
 
 func (r *Resources) Merge(resources *Resources) {
@@ -126,10 +209,177 @@ func (o OptionBlock) GetMetadata(key string) (string, bool) {
 	return strings.TrimSpace(data), true
 }
 
+// GetCloudServices 返回要列出的服务，命令行中未指定时使用配置中的 cloud_services
+func (o OptionBlock) GetCloudServices(cs goflags.StringSlice) []string {
+	if len(cs) == 0 || cs[0] == "all" {
+		cloudServices, _ := o.GetMetadata("cloud_services")
+		return strings.Split(cloudServices, ",")
+	}
+	return cs
+}
+
+// RegionFilter 根据配置中的 regions 和 exclude_regions 筛选要列出的区域，区域名支持以 * 结尾的前缀匹配
+type RegionFilter struct {
+	include []string
+	exclude []string
+}
+
+// GetRegionFilter 返回配置中的区域筛选条件
+func (o OptionBlock) GetRegionFilter() RegionFilter {
+	var filter RegionFilter
+	if regions, ok := o.GetMetadata("regions"); ok {
+		filter.include = splitRegions(regions)
+	}
+	if regions, ok := o.GetMetadata("exclude_regions"); ok {
+		filter.exclude = splitRegions(regions)
+	}
+	return filter
+}
+
+// Allow 判断是否需要列出这个区域
+func (f RegionFilter) Allow(region string) bool {
+	for _, pattern := range f.exclude {
+		if matchRegion(pattern, region) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, pattern := range f.include {
+		if matchRegion(pattern, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 返回需要列出的区域
+func (f RegionFilter) Filter(regions []string) []string {
+	var result []string
+	for _, region := range regions {
+		if f.Allow(region) {
+			result = append(result, region)
+		}
+	}
+	return result
+}
+
+func splitRegions(regions string) []string {
+	var result []string
+	for _, region := range strings.Split(regions, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			result = append(result, region)
+		}
+	}
+	return result
+}
+
+func matchRegion(pattern, region string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(strings.ToLower(region), strings.ToLower(prefix))
+	}
+	return strings.EqualFold(pattern, region)
+}
+
+// Endpoints 是配置中以 <服务名>_endpoint 指定的服务地址，用于专有云或本地测试环境
+type Endpoints map[string]string
+
+// EndpointKeySuffix 是配置中服务地址字段名的后缀
+const EndpointKeySuffix = "_endpoint"
+
+// GetEndpoints 返回配置中指定的服务地址
+func (o OptionBlock) GetEndpoints() Endpoints {
+	endpoints := make(Endpoints)
+	for key := range o {
+		service, ok := strings.CutSuffix(key, EndpointKeySuffix)
+		if !ok || service == "" {
+			continue
+		}
+		if endpoint, ok := o.GetMetadata(key); ok {
+			endpoints[service] = endpoint
+		}
+	}
+	return endpoints
+}
+
+// Get 返回配置中指定的服务地址，地址中的 {region} 会被替换为区域名
+func (e Endpoints) Get(service, region string) (string, bool) {
+	endpoint, ok := e[service]
+	if !ok {
+		return "", false
+	}
+	return strings.ReplaceAll(endpoint, "{region}", region), true
+}
+
+// Resolve 返回服务地址，配置中未指定时返回 defaultEndpoint
+func (e Endpoints) Resolve(service, region, defaultEndpoint string) string {
+	if endpoint, ok := e.Get(service, region); ok {
+		return endpoint
+	}
+	return defaultEndpoint
+}
+
+// SplitEndpoint 将服务地址拆分为协议和主机，未填写协议时使用 https
+func SplitEndpoint(endpoint string) (scheme, host string) {
+	scheme, host, ok := strings.Cut(endpoint, "://")
+	if !ok {
+		return "https", strings.TrimSuffix(endpoint, "/")
+	}
+	return strings.ToLower(scheme), strings.TrimSuffix(host, "/")
+}
+
+// EndpointURL 返回带有协议的服务地址
+func EndpointURL(endpoint string) string {
+	scheme, host := SplitEndpoint(endpoint)
+	return scheme + "://" + host
+}
+
 // Other
 
+// NewProbeResult 根据云服务商返回的错误码判断服务的访问状态，错误码包含 unactivatedCodes 或
+// deniedCodes 中的任意一个时分别判定为服务未开通和无权限
+func NewProbeResult(provider, id, service, code string, err error, deniedCodes, unactivatedCodes []string) *ProbeResult {
+	return newProbeResult(provider, id, service, code, err, deniedCodes, unactivatedCodes, func(code, expected string) bool {
+		return strings.Contains(strings.ToLower(code), strings.ToLower(expected))
+	})
+}
+
+// NewExactProbeResult 与 NewProbeResult 相同，但错误码必须与 unactivatedCodes 或 deniedCodes 中的
+// 某一项完全一致，适用于错误码层级明确、不能按子串匹配的云服务商
+func NewExactProbeResult(provider, id, service, code string, err error, deniedCodes, unactivatedCodes []string) *ProbeResult {
+	return newProbeResult(provider, id, service, code, err, deniedCodes, unactivatedCodes, strings.EqualFold)
+}
+
+func newProbeResult(provider, id, service, code string, err error, deniedCodes, unactivatedCodes []string,
+	match func(code, expected string) bool) *ProbeResult {
+	result := &ProbeResult{Provider: provider, ID: id, Service: service, Status: ProbeAllowed}
+	if err == nil {
+		return result
+	}
+	result.Status = ProbeError
+	result.Code = code
+	result.Message = err.Error()
+	if code == "" {
+		return result
+	}
+	for _, unactivatedCode := range unactivatedCodes {
+		if match(code, unactivatedCode) {
+			result.Status = ProbeUnactivated
+			return result
+		}
+	}
+	for _, deniedCode := range deniedCodes {
+		if match(code, deniedCode) {
+			result.Status = ProbeDenied
+			return result
+		}
+	}
+	return result
+}
+
 func NewResources() *Resources {
-	return &Resources{items: make([]*Resource, 0)}
+	return &Resources{items: make([]*Resource, 0), uniqueMap: &sync.Map{}}
 }
 
 func SetThreads(threads int) {
@@ -7,8 +7,28 @@ const (
 	AccessKey     = "access_key"
 	SecretKey     = "secret_key"
 	SessionToken  = "session_token"
+
+	CredentialsFile = "credentials_file"
+	Profile         = "profile"
+
+	RoleArn         = "role_arn"
+	RoleSessionName = "role_session_name"
+	ExternalId      = "external_id"
+	RoleDuration    = "role_duration"
+
+	ResourceDirectory     = "resource_directory"
+	ResourceDirectoryRole = "resource_directory_role"
+	AccountId             = "account_id"
+
+	Regions        = "regions"
+	ExcludeRegions = "exclude_regions"
+
+	Proxy = "proxy"
 )
 
+// Include 用于在配置文件中引入其他配置文件，它需要单独写成一个配置
+const Include = "include"
+
 const (
 	Aliyun   = "aliyun"
 	Tencent  = "tencent"
@@ -19,3 +39,25 @@ const (
 	QiNiu    = "qiniu"
 	YiDong   = "yidong"
 )
+
+// ProviderServices 是每个云服务商支持列出的服务
+var ProviderServices = map[string][]string{
+	Aliyun:   {"ecs", "oss", "rds", "fc", "domain", "slb", "alb", "nlb", "eip", "alidns", "cdn", "dcdn", "ack", "redis", "mongodb", "polardb", "elasticsearch", "apigateway", "sae", "swas", "nat"},
+	Tencent:  {"cvm", "lh", "cos"},
+	Huawei:   {"obs"},
+	TianYi:   {"oos"},
+	Baidu:    {"bos", "bcc"},
+	LianTong: {"oss"},
+	QiNiu:    {"kodo"},
+	YiDong:   {"eos"},
+}
+
+// ConfigKeys 是配置中所有可用的字段
+var ConfigKeys = []string{
+	Provider, Id, CloudServices, AccessKey, SecretKey, SessionToken,
+	CredentialsFile, Profile,
+	RoleArn, RoleSessionName, ExternalId, RoleDuration,
+	ResourceDirectory, ResourceDirectoryRole, AccountId,
+	Regions, ExcludeRegions,
+	Proxy,
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+	"os"
+	"strings"
+	"sync"
+)
+
+// 加密的配置文件以 encryptedConfigHeader 开头，之后是 base64 编码的 salt、nonce 和 AES-GCM 密文
+const (
+	encryptedConfigHeader = "# lc encrypted config v1\n"
+	saltSize              = 16
+	keySize               = 32
+)
+
+// 用于解密配置文件的口令来源
+const (
+	PassphraseEnv = "LC_CONFIG_PASSPHRASE"
+	KeyFileEnv    = "LC_CONFIG_KEY_FILE"
+)
+
+// ConfigKeyFile 是保存配置文件口令的文件路径，为空时使用 LC_CONFIG_KEY_FILE 环境变量
+var ConfigKeyFile string
+
+var (
+	cachedPassphrase string
+	passphraseMu     sync.Mutex
+)
+
+var ErrWrongPassphrase = errors.New("口令错误或配置文件已损坏")
+
+// IsEncryptedConfig 判断配置文件内容是否已加密
+func IsEncryptedConfig(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedConfigHeader))
+}
+
+// EncryptConfig 使用口令加密配置文件内容，密钥通过 scrypt 从口令中派生
+func EncryptConfig(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	payload := append(append(salt, nonce...), gcm.Seal(nil, nonce, data, nil)...)
+	return []byte(encryptedConfigHeader + base64.StdEncoding.EncodeToString(payload) + "\n"), nil
+}
+
+// DecryptConfig 使用口令解密配置文件内容
+func DecryptConfig(data []byte, passphrase string) ([]byte, error) {
+	if !IsEncryptedConfig(data) {
+		return nil, errors.New("配置文件未加密")
+	}
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data[len(encryptedConfigHeader):])))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	if len(payload) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	gcm, err := newGCM(passphrase, payload[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	payload = payload[saltSize:]
+	if len(payload) < gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ConfigPassphrase 获取配置文件的口令，依次使用 LC_CONFIG_PASSPHRASE 环境变量、口令文件和终端输入，
+// confirm 为 true 时需要在终端中输入两次口令
+func ConfigPassphrase(confirm bool) (string, error) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	if cachedPassphrase != "" {
+		return cachedPassphrase, nil
+	}
+	passphrase, err := readPassphrase(confirm)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("口令不能为空")
+	}
+	cachedPassphrase = passphrase
+	return passphrase, nil
+}
+
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	keyFile := ConfigKeyFile
+	if keyFile == "" {
+		keyFile = os.Getenv(KeyFileEnv)
+	}
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", fmt.Errorf("无法读取口令文件 %s: %s", keyFile, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("配置文件已加密，请通过 %s 环境变量或口令文件提供口令", PassphraseEnv)
+	}
+	passphrase, err := promptPassphrase("请输入配置文件口令: ")
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	again, err := promptPassphrase("请再次输入配置文件口令: ")
+	if err != nil {
+		return "", err
+	}
+	if again != passphrase {
+		return "", errors.New("两次输入的口令不一致")
+	}
+	return passphrase, nil
+}
+
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(passphrase), err
+}
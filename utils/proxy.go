@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/wgpsec/lc/pkg/schema"
+	"net/http"
+	"net/url"
+)
+
+// proxySchemes 是支持的代理协议
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// GetProxy 返回配置中的代理地址，未配置时返回空字符串
+func GetProxy(options schema.OptionBlock) (string, error) {
+	proxy, ok := options.GetMetadata(Proxy)
+	if !ok {
+		return "", nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" || !Contains(proxySchemes, proxyURL.Scheme) {
+		return "", fmt.Errorf("无效的代理地址 %s，仅支持 http、https 和 socks5 代理", proxy)
+	}
+	return proxy, nil
+}
+
+// ProxyTransport 返回通过代理发送请求的 Transport，proxy 为空时与默认的 Transport 一样使用环境变量中的代理
+func ProxyTransport(proxy string) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		if proxyURL, err := url.Parse(proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+	return transport
+}
+
+// ProxyHTTPClient 返回通过代理发送请求的 http.Client
+func ProxyHTTPClient(proxy string) *http.Client {
+	return &http.Client{Transport: ProxyTransport(proxy)}
+}
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/wgpsec/lc/pkg/schema"
 	"gopkg.in/yaml.v3"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -65,17 +68,125 @@ func RemoveRepeatedElement(arr []string) (newArr []string) {
 
 // 文件处理
 
+// ReadConfig 读取配置文件，配置文件已加密时会先获取口令解密。configFile 是目录时会按文件名顺序读取目录下的
+// .yaml 和 .yml 文件，配置中的 include 会引入匹配的其他配置文件，所有配置的 id 都不能重复
 func ReadConfig(configFile string) (schema.Options, error) {
-	var config schema.Options
+	loader := &configLoader{loaded: make(map[string]bool), ids: make(map[string]string)}
+	if err := loader.load(configFile); err != nil {
+		return nil, err
+	}
+	if len(loader.config) == 0 {
+		return nil, io.EOF
+	}
+	return loader.config, nil
+}
 
-	file, err := os.Open(configFile)
+// configLoader 合并多个配置文件中的配置
+type configLoader struct {
+	config schema.Options
+	// loaded 记录已读取的配置文件，避免 include 循环引用或重复读取同一个文件
+	loaded map[string]bool
+	// ids 记录每个配置 id 所在的配置文件
+	ids map[string]string
+}
+
+func (l *configLoader) load(path string) error {
+	info, err := os.Stat(path)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !info.IsDir() {
+		return l.loadFile(path)
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
 	}
-	defer file.Close()
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if entry.IsDir() || strings.HasPrefix(name, ".") || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		if err := l.loadFile(filepath.Join(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if err := yaml.NewDecoder(file).Decode(&config); err != nil {
-		return nil, err
+func (l *configLoader) loadFile(configFile string) error {
+	absPath, err := filepath.Abs(configFile)
+	if err != nil {
+		return err
+	}
+	if l.loaded[absPath] {
+		return nil
+	}
+	l.loaded[absPath] = true
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	if IsEncryptedConfig(data) {
+		passphrase, err := ConfigPassphrase(false)
+		if err != nil {
+			return err
+		}
+		if data, err = DecryptConfig(data, passphrase); err != nil {
+			return err
+		}
+	}
+
+	var config schema.Options
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&config); err != nil && err != io.EOF {
+		return fmt.Errorf("%s: %s", configFile, err)
+	}
+	for _, block := range config {
+		if pattern, ok := block[Include]; ok {
+			if len(block) > 1 {
+				return fmt.Errorf("%s: include 不能与其他字段写在同一个配置中", configFile)
+			}
+			if err := l.include(filepath.Dir(absPath), pattern); err != nil {
+				return err
+			}
+			continue
+		}
+		if id, ok := block.GetMetadata(Id); ok {
+			if file, ok := l.ids[id]; ok {
+				if file == configFile {
+					return fmt.Errorf("配置 id %s 在 %s 中重复", id, configFile)
+				}
+				return fmt.Errorf("配置 id %s 在 %s 和 %s 中重复", id, file, configFile)
+			}
+			l.ids[id] = configFile
+		}
+		l.config = append(l.config, block)
+	}
+	return nil
+}
+
+// include 读取与 pattern 匹配的配置文件或目录，相对路径以当前配置文件所在的目录为基准
+func (l *configLoader) include(dir, pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(pattern, "~/") {
+		pattern = filepath.Join(home, pattern[2:])
+	}
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("无效的 include 路径 %s: %s", pattern, err)
+	}
+	if len(matches) == 0 && !strings.ContainsAny(pattern, "*?[") {
+		return fmt.Errorf("找不到 include 引入的配置文件 %s", pattern)
+	}
+	for _, match := range matches {
+		if err := l.load(match); err != nil {
+			return err
+		}
 	}
-	return config, nil
+	return nil
 }